@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/guionardo/git_sync_status/internal/cache"
+)
+
+func openResultCache() *cache.Store {
+	dir, err := cache.DefaultDir()
+	if err != nil {
+		return nil
+	}
+	return cache.NewStore(dir, cache.DefaultMaxAge)
+}
+
+func runCache(args []string) {
+	if len(args) != 1 || args[0] != "clear" {
+		fmt.Fprintln(os.Stderr, "usage: git-sync-status cache clear")
+		os.Exit(1)
+	}
+	store := openResultCache()
+	if store == nil {
+		fmt.Fprintln(os.Stderr, "error: no user cache directory available")
+		os.Exit(1)
+	}
+	if err := store.Clear(); err != nil {
+		fmt.Fprintf(os.Stderr, "error clearing cache: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("cleared %s\n", store.Dir())
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/guionardo/git_sync_status/internal/tui"
+)
+
+func printFormatted[T any](tmpl *template.Template, items []T) {
+	for _, item := range items {
+		out, err := tui.ExecuteFormat(tmpl, item)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error executing --format template: %v\n", err)
+			os.Exit(1)
+		}
+		if out == "" {
+			continue
+		}
+		if !strings.HasSuffix(out, "\n") {
+			out += "\n"
+		}
+		fmt.Print(out)
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/history"
+	"github.com/guionardo/git_sync_status/internal/service"
+	"github.com/guionardo/git_sync_status/internal/tui"
+)
+
+func openHistoryStore() *history.Store {
+	path, err := history.DefaultPath()
+	if err != nil {
+		return nil
+	}
+	return history.NewStore(path)
+}
+
+func recordHistory(analyzer *service.Analyzer, enabled bool) *history.Store {
+	if !enabled {
+		return nil
+	}
+	store := openHistoryStore()
+	if store == nil {
+		fmt.Fprintln(os.Stderr, "error: no user cache directory available for --history")
+		os.Exit(1)
+	}
+	analyzer.WithHistory(store)
+	return store
+}
+
+func runHistory(args []string) {
+	fs := flag.NewFlagSet("history", flag.ExitOnError)
+	repoPath := fs.String("path", ".", "Repository whose history is shown")
+	branch := fs.String("branch", "", "Only show this branch")
+	days := fs.Int("days", 30, "Show sample counts and ahead/behind series for the last this many days")
+	all := fs.Bool("all", false, "Show every repository in the history store")
+	jsonOut := fs.Bool("json", false, "Print per-branch summaries as JSON")
+	_ = fs.Parse(args)
+
+	store := openHistoryStore()
+	if store == nil {
+		fmt.Fprintln(os.Stderr, "error: no user cache directory available")
+		os.Exit(1)
+	}
+
+	repos := []string{history.RepoKey(*repoPath)}
+	if *all {
+		found, err := store.Repos()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error reading history: %v\n", err)
+			os.Exit(1)
+		}
+		repos = found
+	}
+
+	now := time.Now()
+	since := now.Add(-time.Duration(*days) * 24 * time.Hour)
+	var summaries []history.Summary
+	for i, repo := range repos {
+		repoSummaries, err := store.Summaries(repo, *branch, since)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error reading history: %v\n", err)
+			os.Exit(1)
+		}
+		summaries = append(summaries, repoSummaries...)
+		if *jsonOut {
+			continue
+		}
+		if len(repos) > 1 {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Println(repo)
+		}
+		fmt.Println(tui.RenderHistoryTable(repoSummaries, now))
+	}
+
+	if *jsonOut {
+		if summaries == nil {
+			summaries = []history.Summary{}
+		}
+		writeJSON(summaries)
+	}
+}
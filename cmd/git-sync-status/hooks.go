@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/guionardo/git_sync_status/internal/gitclient"
+	"github.com/guionardo/git_sync_status/internal/hooks"
+	"github.com/guionardo/git_sync_status/internal/service"
+)
+
+func runInstallHooks(args []string, uninstall bool) {
+	name := "install-hooks"
+	if uninstall {
+		name = "uninstall-hooks"
+	}
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	repoPath := fs.String("path", ".", "Repository path whose hooks are managed")
+	_ = fs.Parse(args)
+
+	client := gitclient.NewShellClient()
+	dir, err := client.HooksDir(context.Background(), *repoPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error locating hooks directory: %v\n", err)
+		os.Exit(1)
+	}
+
+	var changes []hooks.Change
+	if uninstall {
+		changes, err = hooks.Uninstall(dir)
+	} else {
+		binary, lookErr := hooks.LookupBinary()
+		if lookErr != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", lookErr)
+			os.Exit(1)
+		}
+		changes, err = hooks.Install(dir, binary)
+	}
+	for _, change := range changes {
+		fmt.Printf("%s: %s\n", change.Hook, change.Action)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error managing hooks: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func runHook(args []string) {
+	fs := flag.NewFlagSet("hook", flag.ExitOnError)
+	repoPath := fs.String("path", ".", "Repository path to inspect")
+	_ = fs.Parse(args)
+	if fs.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "usage: git-sync-status hook <pre-push|post-checkout> [args...]")
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	client := gitclient.NewShellClient()
+	hookArgs := fs.Args()[1:]
+
+	switch fs.Arg(0) {
+	case "pre-push":
+		remote := "origin"
+		if len(hookArgs) > 0 && hookArgs[0] != "" {
+			remote = hookArgs[0]
+		}
+		configured, _ := client.ConfigValue(ctx, *repoPath, hooks.ConfigPrePush)
+		mode := hooks.PrePushMode(configured)
+		if mode == hooks.ModeOff {
+			return
+		}
+		updates, err := hooks.ParsePushUpdates(os.Stdin)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error reading pushed refs: %v\n", err)
+			os.Exit(1)
+		}
+		analyzer := service.NewAnalyzer(client, remote)
+		blocked := false
+		for _, update := range updates {
+			result, ok := analyzer.AnalyzePush(ctx, *repoPath, update)
+			if !ok {
+				continue
+			}
+			block, message := hooks.PrePushVerdict(result, mode)
+			fmt.Fprintln(os.Stderr, message)
+			blocked = blocked || block
+		}
+		if blocked {
+			os.Exit(1)
+		}
+	case "post-checkout":
+		if len(hookArgs) < 3 || hookArgs[2] != "1" {
+			return
+		}
+		configured, _ := client.ConfigValue(ctx, *repoPath, hooks.ConfigPostCheckout)
+		if hooks.PostCheckoutMode(configured) == hooks.ModeOff {
+			return
+		}
+		remote := "origin"
+		if branch, err := client.CurrentBranch(ctx, *repoPath); err == nil && branch != "" {
+			if configuredRemote, err := client.ConfigValue(ctx, *repoPath, "branch."+branch+".remote"); err == nil && configuredRemote != "" {
+				remote = configuredRemote
+			}
+		}
+		result := service.NewAnalyzer(client, remote).AnalyzeLocal(ctx, *repoPath)
+		fmt.Println(hooks.Summary(result))
+	default:
+		fmt.Fprintf(os.Stderr, "unknown hook %q\n", fs.Arg(0))
+		os.Exit(1)
+	}
+}
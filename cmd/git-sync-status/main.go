@@ -6,24 +6,138 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
+	"text/template"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/guionardo/git_sync_status/internal/domain"
 	"github.com/guionardo/git_sync_status/internal/gitclient"
+	"github.com/guionardo/git_sync_status/internal/output"
 	"github.com/guionardo/git_sync_status/internal/service"
 	"github.com/guionardo/git_sync_status/internal/tui"
 )
 
+const exitRiskyPush = 2
+
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "install-hooks":
+			runInstallHooks(os.Args[2:], false)
+			return
+		case "uninstall-hooks":
+			runInstallHooks(os.Args[2:], true)
+			return
+		case "hook":
+			runHook(os.Args[2:])
+			return
+		case "prompt":
+			runPrompt(os.Args[2:])
+			return
+		case "cache":
+			runCache(os.Args[2:])
+			return
+		case "report":
+			runReport(os.Args[2:])
+			return
+		case "serve-metrics":
+			runServeMetrics(os.Args[2:])
+			return
+		case "serve":
+			runServe(os.Args[2:])
+			return
+		case "watch":
+			runWatch(os.Args[2:])
+			return
+		case "webhook":
+			runWebhook(os.Args[2:])
+			return
+		case "history":
+			runHistory(os.Args[2:])
+			return
+		}
+	}
+
 	repoPath := flag.String("path", ".", "Repository path to inspect")
 	remote := flag.String("remote", "origin", "Remote name to compare against")
 	plain := flag.Bool("plain", false, "Print plain text status and exit")
 	jsonOut := flag.Bool("json", false, "Print JSON status and exit")
 	listBranches := flag.Bool("list-branches", false, "List local branches and exit")
+	allBranches := flag.Bool("all-branches", false, "Print sync status and age of every local branch and exit")
+	staleDays := flag.Int("stale-days", int(service.DefaultStaleAfter.Hours()/24), "Flag branches as STALE when their last commit and creation are older than this many days (0 disables)")
+	lostWork := flag.Bool("lost-work", false, "List commits not reachable from any remote-tracking ref (branches, HEAD reflog, stashes) and exit")
+	ci := flag.Bool("ci", false, "Print plain (or --json) status and exit with a non-zero code when the push is risky")
+	maxFileSize := flag.Int64("max-file-size", service.DefaultMaxFileSize, "Flag outgoing files larger than this many bytes as RISKY_PUSH")
+	remoteBranches := flag.Bool("remote-branches", false, "List remote branches not tracked by any local branch and exit")
+	noCache := flag.Bool("no-cache", false, "Ignore and do not update the on-disk result cache")
+	scanDir := flag.String("scan-dir", "", "Analyze every repository found under this directory and exit")
+	scanDepth := flag.Int("scan-depth", service.DefaultScanDepth, "Maximum directory depth searched by --scan-dir")
+	format := flag.String("format", "", "Print each result (or branch row) with this Go template and exit")
+	recordToHistory := flag.Bool("history", false, "Append every analysis to the local history store and show ahead/behind sparklines in the TUI")
+	outputFormat := flag.String("output", "", "Print results in this machine-readable format and exit: "+strings.Join(output.Names(), ", "))
 	flag.Parse()
 
+	if *outputFormat != "" {
+		checkOutputFormat(*outputFormat)
+	}
+
+	var tmpl *template.Template
+	if *format != "" {
+		parsed, err := tui.ParseFormat(*format)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error parsing --format template: %v\n", err)
+			os.Exit(1)
+		}
+		tmpl = parsed
+	}
+
 	client := gitclient.NewShellClient()
-	analyzer := service.NewAnalyzer(client, *remote)
+	opts := service.Options{
+		StaleAfter:  staleAfterDays(*staleDays),
+		MaxFileSize: *maxFileSize,
+	}
+	analyzer := service.NewAnalyzer(client, *remote).WithOptions(opts)
+	cached := service.NewAnalyzer(client, *remote).WithOptions(opts)
+	if store := openResultCache(); store != nil && !*noCache {
+		cached.WithCache(store)
+	}
+	historyStore := recordHistory(analyzer, *recordToHistory)
+	recordHistory(cached, *recordToHistory)
+
+	if *scanDir != "" {
+		repos, err := service.DiscoverRepositories(*scanDir, *scanDepth)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error scanning repositories: %v\n", err)
+			os.Exit(1)
+		}
+		if *outputFormat != "" && tmpl == nil {
+			r := newRenderer(*outputFormat)
+			cached.StreamRepositories(context.Background(), repos, service.DefaultScanWorkers, func(result domain.Result) {
+				writeRecord(r, result)
+			})
+			closeRenderer(r)
+			return
+		}
+		results := cached.AnalyzeRepositories(context.Background(), repos)
+		switch {
+		case tmpl != nil:
+			printFormatted(tmpl, results)
+		case *jsonOut:
+			writeJSON(results)
+		case *plain:
+			for i, result := range results {
+				if i > 0 {
+					fmt.Println()
+				}
+				printPlainResult(result)
+			}
+		default:
+			fmt.Println(tui.RenderRepositoryTable(results))
+		}
+		return
+	}
 
 	if *listBranches {
 		branches, err := analyzer.ScanLocalBranches(context.Background(), *repoPath)
@@ -31,41 +145,144 @@ func main() {
 			fmt.Fprintf(os.Stderr, "error listing branches: %v\n", err)
 			os.Exit(1)
 		}
-		fmt.Println(tui.RenderBranchList(branches))
+		switch {
+		case tmpl != nil:
+			printFormatted(tmpl, branches)
+		case *outputFormat != "":
+			renderRecords(*outputFormat, branches)
+		default:
+			fmt.Println(tui.RenderBranchList(branches))
+		}
 		return
 	}
 
-	if *jsonOut {
-		result := analyzer.Analyze(context.Background(), *repoPath)
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		if err := enc.Encode(result); err != nil {
-			fmt.Fprintf(os.Stderr, "error encoding JSON: %v\n", err)
+	if *allBranches {
+		rows, err := analyzer.AnalyzeAllBranches(context.Background(), *repoPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error analyzing branches: %v\n", err)
 			os.Exit(1)
 		}
+		switch {
+		case tmpl != nil:
+			printFormatted(tmpl, rows)
+		case *outputFormat != "":
+			renderRecords(*outputFormat, rows)
+		case *jsonOut:
+			writeJSON(rows)
+		case *plain:
+			printPlainBranches(rows)
+		default:
+			fmt.Println(tui.RenderBranchTable(rows))
+		}
 		return
 	}
 
-	if *plain {
-		result := analyzer.Analyze(context.Background(), *repoPath)
-		fmt.Printf("path=%s\nbranch=%s\nupstream=%s\nstatus=%s\nahead=%d\nbehind=%d\n",
-			result.RepoPath, result.Branch, result.Upstream, result.Status, result.Ahead, result.Behind)
-		if len(result.Flags) > 0 {
-			fmt.Printf("flags=%v\n", result.Flags)
+	if *lostWork {
+		audit, err := analyzer.AuditLostWork(context.Background(), *repoPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error auditing lost work: %v\n", err)
+			os.Exit(1)
 		}
-		if len(result.Actions) > 0 {
-			fmt.Printf("actions=%v\n", result.Actions)
+		switch {
+		case tmpl != nil:
+			printFormatted(tmpl, []service.LostWorkAudit{audit})
+		case *outputFormat != "":
+			renderRecords(*outputFormat, []service.LostWorkAudit{audit})
+		case *jsonOut:
+			writeJSON(audit)
+		case *plain:
+			fmt.Printf("path=%s\nunbacked_commits=%d\n", audit.RepoPath, audit.TotalCommits)
+			for _, group := range audit.Groups {
+				for _, c := range group.Commits {
+					fmt.Printf("commit=%s source=%s subject=%s\n", c.SHA, group.Source, c.Subject)
+				}
+			}
+		default:
+			fmt.Println(tui.RenderLostWorkAudit(audit))
 		}
-		if result.Err != "" {
-			fmt.Printf("error=%s\n", result.Err)
+		return
+	}
+
+	if *remoteBranches {
+		rows, err := analyzer.ScanRemoteOnlyBranches(context.Background(), *repoPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error listing remote branches: %v\n", err)
+			os.Exit(1)
+		}
+		switch {
+		case tmpl != nil:
+			printFormatted(tmpl, rows)
+		case *outputFormat != "":
+			renderRecords(*outputFormat, rows)
+		case *jsonOut:
+			writeJSON(rows)
+		default:
+			fmt.Println(tui.RenderRemoteBranchTable(rows))
+		}
+		return
+	}
+
+	if *ci {
+		result := cached.Analyze(context.Background(), *repoPath)
+		switch {
+		case tmpl != nil:
+			printFormatted(tmpl, []domain.Result{result})
+		case *outputFormat != "":
+			renderRecords(*outputFormat, []domain.Result{result})
+		case *jsonOut:
+			writeJSON(result)
+		default:
+			printPlainResult(result)
+		}
+		if result.HasFlag("RISKY_PUSH") {
+			os.Exit(exitRiskyPush)
 		}
 		return
 	}
 
+	if tmpl != nil {
+		printFormatted(tmpl, []domain.Result{cached.Analyze(context.Background(), *repoPath)})
+		return
+	}
+
+	if *outputFormat != "" {
+		renderRecords(*outputFormat, []domain.Result{cached.Analyze(context.Background(), *repoPath)})
+		return
+	}
+
+	if *jsonOut {
+		writeJSON(cached.Analyze(context.Background(), *repoPath))
+		return
+	}
+
+	if *plain {
+		printPlainResult(cached.Analyze(context.Background(), *repoPath))
+		return
+	}
+
 	m := tui.NewModel(analyzer, *repoPath)
+	if historyStore != nil {
+		m = m.WithHistory(historyStore)
+	}
 	p := tea.NewProgram(m)
 	if _, err := p.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "runtime error: %v\n", err)
 		os.Exit(1)
 	}
 }
+
+func staleAfterDays(days int) time.Duration {
+	if days <= 0 {
+		return service.StaleCheckDisabled
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+func writeJSON(v any) {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		fmt.Fprintf(os.Stderr, "error encoding JSON: %v\n", err)
+		os.Exit(1)
+	}
+}
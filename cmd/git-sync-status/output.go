@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/guionardo/git_sync_status/internal/output"
+)
+
+func checkOutputFormat(name string) {
+	if _, err := output.New(name, io.Discard); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func newRenderer(name string) output.Renderer {
+	r, err := output.New(name, os.Stdout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	return r
+}
+
+func writeRecord(r output.Renderer, record any) {
+	if err := r.Write(record); err != nil {
+		fmt.Fprintf(os.Stderr, "error writing output: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func closeRenderer(r output.Renderer) {
+	if err := r.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "error writing output: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func renderRecords[T any](name string, records []T) {
+	r := newRenderer(name)
+	for _, record := range records {
+		writeRecord(r, record)
+	}
+	closeRenderer(r)
+}
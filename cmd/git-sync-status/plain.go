@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+	"github.com/guionardo/git_sync_status/internal/service"
+)
+
+func printPlainResult(result domain.Result) {
+	fmt.Printf("path=%s\nbranch=%s\nupstream=%s\nstatus=%s\nahead=%d\nbehind=%d\n",
+		result.RepoPath, result.Branch, result.Upstream, result.Status, result.Ahead, result.Behind)
+	if len(result.Flags) > 0 {
+		fmt.Printf("flags=%v\n", result.Flags)
+	}
+	if result.Detached != nil {
+		fmt.Printf("detached_commit=%s\ndetached_on_remote=%t\ncontaining_branches=%v\ncontaining_remote_branches=%v\ncontaining_tags=%v\n",
+			result.Detached.Commit, result.Detached.OnRemote, result.Detached.LocalBranches,
+			result.Detached.RemoteBranches, result.Detached.Tags)
+	}
+	if result.Policy != nil {
+		fmt.Printf("unsigned_commits=%v\nforeign_author_commits=%v\n", result.Policy.UnsignedCommits, result.Policy.ForeignAuthorCommits)
+	}
+	if result.Tags != nil {
+		fmt.Printf("tags_unpushed=%v\ntags_missing=%v\n", result.Tags.LocalOnly, result.Tags.RemoteOnly)
+		for _, m := range result.Tags.Mismatched {
+			fmt.Printf("tag_mismatch=%s local=%s remote=%s\n", m.Name, m.LocalCommit, m.RemoteCommit)
+		}
+	}
+	if result.LFS != nil {
+		fmt.Printf("lfs_tracked=%d\nlfs_local=%d\n", result.LFS.TrackedFiles, result.LFS.LocalObjects)
+		if result.LFS.Unknown {
+			fmt.Println("lfs_unpushed=unknown")
+		} else {
+			fmt.Printf("lfs_unpushed=%d\nlfs_unpushed_bytes=%d\n", result.LFS.UnpushedObjects, result.LFS.UnpushedBytes)
+		}
+	}
+	for _, wt := range result.Worktrees {
+		fmt.Printf("worktree=%s branch=%s dirty=%t locked=%t prunable=%t\n",
+			wt.Path, wt.Branch, wt.Dirty, wt.Locked, wt.Prunable)
+	}
+	for _, c := range result.Conflicts {
+		fmt.Printf("conflicts=%s:%s\n", c.Against, strings.Join(c.Files, ","))
+	}
+	for _, f := range result.Findings {
+		fmt.Printf("finding=%s commit=%s path=%s\n", f.Rule, f.Commit, f.Path)
+	}
+	if len(result.Actions) > 0 {
+		fmt.Printf("actions=%v\n", result.Actions)
+	}
+	if result.Err != "" {
+		fmt.Printf("error=%s\n", result.Err)
+	}
+}
+
+func printPlainBranches(rows []service.BranchStatus) {
+	for i, row := range rows {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("branch=%s\nupstream=%s\nstatus=%s\nahead=%d\nbehind=%d\n",
+			row.Branch, row.Upstream, row.Status, row.Ahead, row.Behind)
+		if !row.LastCommitDate.IsZero() {
+			fmt.Printf("last_commit=%s\n", row.LastCommitDate.Format(time.RFC3339))
+		}
+		if row.LastCommitAuthor != "" {
+			fmt.Printf("last_author=%s\n", row.LastCommitAuthor)
+		}
+		if !row.CreatedAt.IsZero() {
+			fmt.Printf("created=%s\n", row.CreatedAt.Format(time.RFC3339))
+		}
+		if row.CreatedApprox {
+			fmt.Println("created_approx=true")
+		}
+		fmt.Printf("stale=%t\n", row.Stale)
+		if row.DefaultBranch != "" {
+			fmt.Printf("default_branch=%s\ndefault_ahead=%d\ndefault_behind=%d\n",
+				row.DefaultBranch, row.DefaultAhead, row.DefaultBehind)
+		}
+		if row.Suggestion != "" {
+			fmt.Printf("suggestion=%s\n", row.Suggestion)
+		}
+		if row.Worktree != "" {
+			fmt.Printf("worktree=%s\n", row.Worktree)
+		}
+		for _, c := range row.Conflicts {
+			fmt.Printf("conflicts=%s:%s\n", c.Against, strings.Join(c.Files, ","))
+		}
+		if len(row.Flags) > 0 {
+			fmt.Printf("flags=%v\n", row.Flags)
+		}
+	}
+}
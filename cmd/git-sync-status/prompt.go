@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/gitclient"
+	"github.com/guionardo/git_sync_status/internal/prompt"
+	"github.com/guionardo/git_sync_status/internal/service"
+)
+
+func runPrompt(args []string) {
+	fs := flag.NewFlagSet("prompt", flag.ExitOnError)
+	repoPath := fs.String("path", ".", "Repository path to inspect")
+	remote := fs.String("remote", "origin", "Remote name to compare against")
+	format := fs.String("format", prompt.DefaultFormat, "Segment format: %b branch, %s status, %a ahead, %B behind, %d dirty, %f flags, %% percent")
+	ttl := fs.Duration("ttl", time.Minute, "Refresh the cached status in the background when it is older than this")
+	initShell := fs.String("init", "", "Print the prompt snippet for bash, zsh, fish or starship and exit")
+	refresh := fs.Bool("refresh", false, "Analyze the repository and rewrite the prompt cache (used by the background refresh)")
+	_ = fs.Parse(args)
+
+	if *initShell != "" {
+		binary, err := os.Executable()
+		if err != nil {
+			binary = "git-sync-status"
+		}
+		snippet, err := prompt.Snippet(*initShell, binary)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Print(snippet)
+		return
+	}
+
+	repo, err := prompt.FindRepo(*repoPath)
+	if err != nil {
+		return
+	}
+
+	if *refresh {
+		if err := refreshPrompt(repo, *remote); err != nil {
+			fmt.Fprintf(os.Stderr, "error writing prompt cache: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	now := time.Now()
+	seg, cached := repo.ReadCache()
+	if repo.NeedsRefresh(seg, cached, *ttl, now) && repo.TryLock(now) {
+		startPromptRefresh(repo, *remote)
+	}
+	if head := repo.Head(); !cached || seg.Branch != head {
+		seg = prompt.Segment{Branch: head}
+	}
+	fmt.Println(prompt.Render(*format, seg))
+}
+
+func refreshPrompt(repo prompt.Repo, remote string) error {
+	defer repo.Unlock()
+	analyzer := service.NewAnalyzer(gitclient.NewShellClient(), remote)
+	seg := prompt.FromResult(analyzer.AnalyzeLocal(context.Background(), repo.WorkTree), time.Now())
+	seg.Branch = repo.Head()
+	return repo.WriteCache(seg)
+}
+
+func startPromptRefresh(repo prompt.Repo, remote string) {
+	binary, err := os.Executable()
+	if err != nil {
+		repo.Unlock()
+		return
+	}
+	devNull, err := os.OpenFile(os.DevNull, os.O_RDWR, 0)
+	if err != nil {
+		repo.Unlock()
+		return
+	}
+	defer devNull.Close()
+	cmd := exec.Command(binary, "prompt", "--refresh", "--path", repo.WorkTree, "--remote", remote)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = devNull, devNull, devNull
+	cmd.SysProcAttr = detachedProcess()
+	if err := cmd.Start(); err != nil {
+		repo.Unlock()
+		return
+	}
+	_ = cmd.Process.Release()
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/guionardo/git_sync_status/internal/gitclient"
+	"github.com/guionardo/git_sync_status/internal/report"
+	"github.com/guionardo/git_sync_status/internal/service"
+)
+
+func runReport(args []string) {
+	fs := flag.NewFlagSet("report", flag.ExitOnError)
+	repoPath := fs.String("path", ".", "Repository path to report on (ignored with --scan-dir)")
+	remote := fs.String("remote", "origin", "Remote name to compare against")
+	scanDir := fs.String("scan-dir", "", "Report on every repository found under this directory")
+	scanDepth := fs.Int("scan-depth", service.DefaultScanDepth, "Maximum directory depth searched by --scan-dir")
+	staleDays := fs.Int("stale-days", int(service.DefaultStaleAfter.Hours()/24), "Flag branches as STALE when their last commit and creation are older than this many days (0 disables)")
+	kind := fs.String("output", "markdown", "Report format: markdown or html")
+	outFile := fs.String("file", "", "Write the report to this file instead of stdout")
+	noCache := fs.Bool("no-cache", false, "Ignore and do not update the on-disk result cache")
+	_ = fs.Parse(args)
+
+	if *kind != "markdown" && *kind != "html" {
+		fmt.Fprintf(os.Stderr, "error: unknown report format %q (expected markdown or html)\n", *kind)
+		os.Exit(1)
+	}
+
+	repos := []string{*repoPath}
+	if *scanDir != "" {
+		found, err := service.DiscoverRepositories(*scanDir, *scanDepth)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error scanning repositories: %v\n", err)
+			os.Exit(1)
+		}
+		repos = found
+	}
+
+	analyzer := service.NewAnalyzer(gitclient.NewShellClient(), *remote).WithOptions(service.Options{
+		StaleAfter: staleAfterDays(*staleDays),
+	})
+	if store := openResultCache(); store != nil && !*noCache {
+		analyzer.WithCache(store)
+	}
+	rep := analyzer.BuildReport(context.Background(), repos)
+
+	if *outFile == "" {
+		if err := writeReport(os.Stdout, *kind, rep); err != nil {
+			fmt.Fprintf(os.Stderr, "error writing report: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	f, err := os.Create(*outFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error creating report: %v\n", err)
+		os.Exit(1)
+	}
+	err = writeReport(f, *kind, rep)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error writing report %s: %v\n", *outFile, err)
+		os.Exit(1)
+	}
+}
+
+func writeReport(w io.Writer, kind string, rep service.Report) error {
+	if kind == "html" {
+		return report.HTML(w, rep)
+	}
+	_, err := io.WriteString(w, report.Markdown(rep))
+	return err
+}
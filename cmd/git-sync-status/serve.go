@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/api"
+	"github.com/guionardo/git_sync_status/internal/gitclient"
+	"github.com/guionardo/git_sync_status/internal/service"
+)
+
+func runServe(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	listen := fs.String("listen", "127.0.0.1:9181", "Address to serve the HTTP API on")
+	interval := fs.Duration("interval", 30*time.Second, "Time between background analyses used to detect status changes")
+	remote := fs.String("remote", "origin", "Remote name to compare against")
+	scanDir := fs.String("scan-dir", "", "Also serve every repository found under this directory")
+	scanDepth := fs.Int("scan-depth", service.DefaultScanDepth, "Maximum directory depth searched by --scan-dir")
+	allowOrigin := fs.String("allow-origin", "", "Value of Access-Control-Allow-Origin for browser dashboards (disabled when empty)")
+	recordToHistory := fs.Bool("history", false, "Append every analysis to the local history store")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: git-sync-status serve [flags] [repo ...]")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	repos, err := collectRepos(fs.Args(), *scanDir, *scanDepth)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error scanning repositories: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	analyzer := service.NewAnalyzer(gitclient.NewShellClient(), *remote)
+	recordHistory(analyzer, *recordToHistory)
+	srv := api.NewServer(analyzer, repos)
+	srv.AllowOrigin = *allowOrigin
+	go srv.Run(ctx, *interval)
+
+	server := &http.Server{Addr: *listen, Handler: srv.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Fprintf(os.Stderr, "serving %d repositories on http://%s\n", len(repos), *listen)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		fmt.Fprintf(os.Stderr, "error serving API: %v\n", err)
+		os.Exit(1)
+	}
+}
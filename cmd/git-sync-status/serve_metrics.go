@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/gitclient"
+	"github.com/guionardo/git_sync_status/internal/metrics"
+	"github.com/guionardo/git_sync_status/internal/service"
+)
+
+func runServeMetrics(args []string) {
+	fs := flag.NewFlagSet("serve-metrics", flag.ExitOnError)
+	listen := fs.String("listen", ":9180", "Address to serve /metrics on")
+	interval := fs.Duration("interval", time.Minute, "Time between analyses of every repository")
+	remote := fs.String("remote", "origin", "Remote name to compare against")
+	scanDir := fs.String("scan-dir", "", "Also export every repository found under this directory")
+	scanDepth := fs.Int("scan-depth", service.DefaultScanDepth, "Maximum directory depth searched by --scan-dir")
+	recordToHistory := fs.Bool("history", false, "Append every analysis to the local history store")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: git-sync-status serve-metrics [flags] [repo ...]")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	repos, err := collectRepos(fs.Args(), *scanDir, *scanDepth)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error scanning repositories: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	analyzer := service.NewAnalyzer(gitclient.NewShellClient(), *remote)
+	recordHistory(analyzer, *recordToHistory)
+	exporter := metrics.NewExporter(analyzer, repos, *interval)
+	go exporter.Run(ctx)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", exporter)
+	server := &http.Server{Addr: *listen, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Fprintf(os.Stderr, "serving metrics for %d repositories on %s/metrics\n", len(repos), *listen)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		fmt.Fprintf(os.Stderr, "error serving metrics: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func collectRepos(args []string, scanDir string, scanDepth int) ([]string, error) {
+	repos := append([]string{}, args...)
+	if scanDir != "" {
+		found, err := service.DiscoverRepositories(scanDir, scanDepth)
+		if err != nil {
+			return nil, err
+		}
+		repos = append(repos, found...)
+	}
+	if len(repos) == 0 {
+		repos = []string{"."}
+	}
+	return repos, nil
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/gitclient"
+	"github.com/guionardo/git_sync_status/internal/notify"
+	"github.com/guionardo/git_sync_status/internal/service"
+	"github.com/guionardo/git_sync_status/internal/watch"
+)
+
+func runWatch(args []string) {
+	fs := flag.NewFlagSet("watch", flag.ExitOnError)
+	interval := fs.Duration("interval", time.Minute, "Time between analyses of every repository")
+	remote := fs.String("remote", "origin", "Remote name to compare against")
+	scanDir := fs.String("scan-dir", "", "Also watch every repository found under this directory")
+	scanDepth := fs.Int("scan-depth", service.DefaultScanDepth, "Maximum directory depth searched by --scan-dir")
+	notifyVia := fs.String("notify", "dbus", "Where to send transition notifications: dbus or none")
+	rateLimit := fs.Duration("rate-limit", 5*time.Minute, "Minimum time between two notifications for the same repository")
+	webhookURL := fs.String("webhook", "", "Also POST each transition to this webhook URL")
+	webhookTmpl := fs.String("webhook-template", notify.TemplateJSON, "Webhook payload template: "+strings.Join(notify.TemplateNames(), ", "))
+	maxPerHour := fs.Int("max-per-hour", 20, "Maximum notifications sent per hour across all repositories (0 disables the cap)")
+	recordToHistory := fs.Bool("history", false, "Append every analysis to the local history store")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: git-sync-status watch [flags] [repo ...]")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	repos, err := collectRepos(fs.Args(), *scanDir, *scanDepth)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error scanning repositories: %v\n", err)
+		os.Exit(1)
+	}
+
+	var notifiers notify.Multi
+	switch *notifyVia {
+	case "dbus":
+		dbusNotifier := notify.NewDBusNotifier()
+		defer dbusNotifier.Close()
+		notifiers = append(notifiers, dbusNotifier)
+	case "none":
+	default:
+		fmt.Fprintf(os.Stderr, "unknown --notify %q (want dbus or none)\n", *notifyVia)
+		os.Exit(1)
+	}
+	if *webhookURL != "" {
+		tmpl, err := notify.BuiltinTemplate(*webhookTmpl)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error loading webhook template: %v\n", err)
+			os.Exit(1)
+		}
+		hook := notify.NewWebhook(*webhookURL, tmpl)
+		hook.Host, _ = os.Hostname()
+		notifiers = append(notifiers, hook)
+	}
+	var notifier notify.Notifier
+	if len(notifiers) > 0 {
+		notifier = notify.NewRateLimited(notifiers, *rateLimit, *maxPerHour, time.Hour)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	analyzer := service.NewAnalyzer(gitclient.NewShellClient(), *remote)
+	recordHistory(analyzer, *recordToHistory)
+	watcher := watch.NewWatcher(analyzer, notifier, repos, *interval)
+	watcher.OnEvent = func(event notify.Event, err error) {
+		fmt.Printf("%s %s\n", event.At.Format(time.TimeOnly), event.Title())
+		switch {
+		case errors.Is(err, notify.ErrRateLimited):
+			fmt.Fprintln(os.Stderr, "  notification suppressed by rate limit")
+		case err != nil:
+			fmt.Fprintf(os.Stderr, "  error sending notification: %v\n", err)
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "watching %d repositories every %s\n", len(repos), *interval)
+	watcher.Run(ctx)
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/template"
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/gitclient"
+	"github.com/guionardo/git_sync_status/internal/notify"
+	"github.com/guionardo/git_sync_status/internal/service"
+)
+
+func runWebhook(args []string) {
+	fs := flag.NewFlagSet("webhook", flag.ExitOnError)
+	url := fs.String("url", os.Getenv("GIT_SYNC_STATUS_WEBHOOK_URL"), "Webhook URL to POST to (default $GIT_SYNC_STATUS_WEBHOOK_URL)")
+	tmplName := fs.String("template", notify.TemplateJSON, "Payload template: "+strings.Join(notify.TemplateNames(), ", "))
+	tmplFile := fs.String("template-file", "", "Render the payload with this Go template file instead of --template")
+	remote := fs.String("remote", "origin", "Remote name to compare against")
+	scanDir := fs.String("scan-dir", "", "Also scan every repository found under this directory")
+	scanDepth := fs.Int("scan-depth", service.DefaultScanDepth, "Maximum directory depth searched by --scan-dir")
+	interval := fs.Duration("interval", 0, "Repeat the scan at this interval instead of exiting after one scan")
+	retries := fs.Int("retries", notify.DefaultWebhookRetries, "Retries after a failed POST (network errors, 429 and 5xx responses)")
+	backoff := fs.Duration("backoff", notify.DefaultWebhookBackoff, "Delay before the first retry, doubled for each further retry")
+	noCache := fs.Bool("no-cache", false, "Ignore and do not update the on-disk result cache")
+	recordToHistory := fs.Bool("history", false, "Append every analysis to the local history store")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: git-sync-status webhook --url <url> [flags] [repo ...]")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	if *url == "" {
+		fmt.Fprintln(os.Stderr, "error: --url (or GIT_SYNC_STATUS_WEBHOOK_URL) is required")
+		os.Exit(1)
+	}
+	tmpl, err := loadWebhookTemplate(*tmplName, *tmplFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error loading webhook template: %v\n", err)
+		os.Exit(1)
+	}
+	repos, err := collectRepos(fs.Args(), *scanDir, *scanDepth)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error scanning repositories: %v\n", err)
+		os.Exit(1)
+	}
+
+	host, _ := os.Hostname()
+	hook := notify.NewWebhook(*url, tmpl)
+	hook.Host = host
+	hook.Retries = *retries
+	hook.Backoff = *backoff
+
+	analyzer := service.NewAnalyzer(gitclient.NewShellClient(), *remote)
+	recordHistory(analyzer, *recordToHistory)
+	if store := openResultCache(); store != nil && !*noCache && *interval == 0 {
+		analyzer.WithCache(store)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if *interval <= 0 {
+		if err := scanAndPost(ctx, analyzer, hook, repos); err != nil {
+			fmt.Fprintf(os.Stderr, "error posting webhook: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	for {
+		if err := scanAndPost(ctx, analyzer, hook, repos); err != nil && ctx.Err() == nil {
+			fmt.Fprintf(os.Stderr, "error posting webhook: %v\n", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func scanAndPost(ctx context.Context, analyzer *service.Analyzer, hook *notify.Webhook, repos []string) error {
+	results := analyzer.AnalyzeRepositories(ctx, repos)
+	payload := notify.BuildPayload(hook.Host, results, time.Now())
+	if len(payload.Problems) == 0 {
+		fmt.Fprintf(os.Stderr, "%d repositories clean, nothing to post\n", len(results))
+		return nil
+	}
+	if err := hook.Send(ctx, payload); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, payload.Title)
+	return nil
+}
+
+func loadWebhookTemplate(name string, file string) (*template.Template, error) {
+	if file == "" {
+		return notify.BuiltinTemplate(name)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return notify.ParseTemplate(string(data))
+}
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"sync"
+)
+
+type Event struct {
+	RepoID string
+	Data   []byte
+}
+
+type broker struct {
+	mu          sync.Mutex
+	subscribers map[chan Event]struct{}
+}
+
+func newBroker() *broker {
+	return &broker{subscribers: map[chan Event]struct{}{}}
+}
+
+func (b *broker) subscribe() chan Event {
+	ch := make(chan Event, 16)
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+	return ch
+}
+
+func (b *broker) unsubscribe(ch chan Event) {
+	b.mu.Lock()
+	delete(b.subscribers, ch)
+	b.mu.Unlock()
+}
+
+func (b *broker) publish(ev Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subscribers {
+		select {
+		case ch <- ev:
+		default:
+		}
+	}
+}
@@ -0,0 +1,297 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+	"github.com/guionardo/git_sync_status/internal/service"
+)
+
+type Analyzer interface {
+	Analyze(ctx context.Context, repoPath string) domain.Result
+	AnalyzeAllBranches(ctx context.Context, repoPath string) ([]service.BranchStatus, error)
+}
+
+type Repo struct {
+	ID         string
+	Path       string
+	Branch     string
+	Status     domain.Status
+	Ahead      int
+	Behind     int
+	Flags      []string
+	AnalyzedAt time.Time
+}
+
+type repoState struct {
+	id         string
+	path       string
+	refreshMu  sync.Mutex
+	result     *domain.Result
+	digest     []byte
+	startedAt  time.Time
+	analyzedAt time.Time
+}
+
+type Server struct {
+	AllowOrigin string
+
+	analyzer  Analyzer
+	now       func() time.Time
+	keepAlive time.Duration
+	events    *broker
+
+	mu    sync.RWMutex
+	order []string
+	repos map[string]*repoState
+}
+
+func NewServer(analyzer Analyzer, repoPaths []string) *Server {
+	s := &Server{
+		analyzer:  analyzer,
+		now:       time.Now,
+		keepAlive: 15 * time.Second,
+		events:    newBroker(),
+		repos:     map[string]*repoState{},
+	}
+	for _, path := range repoPaths {
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+		id := RepoID(path)
+		if _, ok := s.repos[id]; ok {
+			continue
+		}
+		s.repos[id] = &repoState{id: id, path: path}
+		s.order = append(s.order, id)
+	}
+	return s
+}
+
+func RepoID(path string) string {
+	sum := sha256.Sum256([]byte(path))
+	return hex.EncodeToString(sum[:6])
+}
+
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos", s.handleRepos)
+	mux.HandleFunc("GET /repos/{id}/status", s.handleStatus)
+	mux.HandleFunc("GET /repos/{id}/branches", s.handleBranches)
+	mux.HandleFunc("POST /repos/{id}/refresh", s.handleRefresh)
+	mux.HandleFunc("GET /events", s.handleEvents)
+	if s.AllowOrigin == "" {
+		return mux
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", s.AllowOrigin)
+		if r.Method == http.MethodOptions {
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		s.RefreshAll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Server) RefreshAll(ctx context.Context) {
+	s.mu.RLock()
+	ids := append([]string{}, s.order...)
+	s.mu.RUnlock()
+	for _, id := range ids {
+		if ctx.Err() != nil {
+			return
+		}
+		s.refresh(ctx, id)
+	}
+}
+
+func (s *Server) refresh(ctx context.Context, id string) (domain.Result, bool) {
+	s.mu.RLock()
+	state, ok := s.repos[id]
+	s.mu.RUnlock()
+	if !ok {
+		return domain.Result{}, false
+	}
+
+	requested := s.now()
+	state.refreshMu.Lock()
+	defer state.refreshMu.Unlock()
+
+	s.mu.RLock()
+	if state.result != nil && state.startedAt.After(requested) {
+		result := *state.result
+		s.mu.RUnlock()
+		return result, true
+	}
+	s.mu.RUnlock()
+
+	started := s.now()
+	result := s.analyzer.Analyze(ctx, state.path)
+	digest, _ := json.Marshal(result)
+
+	s.mu.Lock()
+	if state.result != nil && state.startedAt.After(started) {
+		result = *state.result
+		s.mu.Unlock()
+		return result, true
+	}
+	changed := !bytes.Equal(state.digest, digest)
+	state.result = &result
+	state.startedAt = started
+	state.digest = digest
+	state.analyzedAt = s.now()
+	summary := state.summary()
+	s.mu.Unlock()
+
+	if changed {
+		data, _ := json.Marshal(struct {
+			Repo   Repo
+			Result domain.Result
+		}{summary, result})
+		s.events.publish(Event{RepoID: id, Data: data})
+	}
+	return result, true
+}
+
+func (r *repoState) summary() Repo {
+	repo := Repo{ID: r.id, Path: r.path, AnalyzedAt: r.analyzedAt}
+	if r.result != nil {
+		repo.Branch = r.result.Branch
+		repo.Status = r.result.Status
+		repo.Ahead = r.result.Ahead
+		repo.Behind = r.result.Behind
+		repo.Flags = r.result.Flags
+	}
+	return repo
+}
+
+func (s *Server) handleRepos(w http.ResponseWriter, _ *http.Request) {
+	s.mu.RLock()
+	repos := make([]Repo, 0, len(s.order))
+	for _, id := range s.order {
+		repos = append(repos, s.repos[id].summary())
+	}
+	s.mu.RUnlock()
+	writeJSON(w, http.StatusOK, repos)
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	s.mu.RLock()
+	state, ok := s.repos[id]
+	var cached *domain.Result
+	if ok {
+		cached = state.result
+	}
+	s.mu.RUnlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("unknown repository %q", id))
+		return
+	}
+	if cached != nil {
+		writeJSON(w, http.StatusOK, cached)
+		return
+	}
+	result, _ := s.refresh(r.Context(), id)
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) handleBranches(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	state, ok := s.repos[r.PathValue("id")]
+	s.mu.RUnlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("unknown repository %q", r.PathValue("id")))
+		return
+	}
+	rows, err := s.analyzer.AnalyzeAllBranches(r.Context(), state.path)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if rows == nil {
+		rows = []service.BranchStatus{}
+	}
+	writeJSON(w, http.StatusOK, rows)
+}
+
+func (s *Server) handleRefresh(w http.ResponseWriter, r *http.Request) {
+	result, ok := s.refresh(r.Context(), r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("unknown repository %q", r.PathValue("id")))
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming not supported")
+		return
+	}
+	filter := r.URL.Query().Get("repo")
+
+	ch := s.events.subscribe()
+	defer s.events.unsubscribe(ch)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+
+	ticker := time.NewTicker(s.keepAlive)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			flusher.Flush()
+		case ev := <-ch:
+			if filter != "" && ev.RepoID != filter {
+				continue
+			}
+			fmt.Fprintf(w, "event: status\ndata: %s\n\n", ev.Data)
+			flusher.Flush()
+		}
+	}
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code int, message string) {
+	writeJSON(w, code, map[string]string{"error": message})
+}
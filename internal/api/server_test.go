@@ -0,0 +1,243 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+	"github.com/guionardo/git_sync_status/internal/service"
+)
+
+type fakeAnalyzer struct {
+	mu      sync.Mutex
+	results map[string]domain.Result
+}
+
+func (f *fakeAnalyzer) Analyze(_ context.Context, repoPath string) domain.Result {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.results[repoPath]
+}
+
+func (f *fakeAnalyzer) AnalyzeAllBranches(context.Context, string) ([]service.BranchStatus, error) {
+	return []service.BranchStatus{{Branch: "main", Status: domain.StatusSynced}}, nil
+}
+
+func (f *fakeAnalyzer) set(path string, result domain.Result) {
+	f.mu.Lock()
+	f.results[path] = result
+	f.mu.Unlock()
+}
+
+func TestServerEndpoints(t *testing.T) {
+	t.Parallel()
+
+	fa := &fakeAnalyzer{results: map[string]domain.Result{
+		"/src/api": {RepoPath: "/src/api", Branch: "main", Status: domain.StatusLate, Behind: 3},
+	}}
+	srv := NewServer(fa, []string{"/src/api", "/src/api"})
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+	id := RepoID("/src/api")
+
+	var repos []Repo
+	getJSON(t, ts.URL+"/repos", http.StatusOK, &repos)
+	if len(repos) != 1 || repos[0].ID != id || repos[0].Status != "" {
+		t.Fatalf("GET /repos = %+v, want one unanalyzed repo", repos)
+	}
+
+	var result domain.Result
+	getJSON(t, ts.URL+"/repos/"+id+"/status", http.StatusOK, &result)
+	if result.Status != domain.StatusLate || result.Behind != 3 {
+		t.Fatalf("GET status = %+v", result)
+	}
+
+	var branches []service.BranchStatus
+	getJSON(t, ts.URL+"/repos/"+id+"/branches", http.StatusOK, &branches)
+	if len(branches) != 1 || branches[0].Branch != "main" {
+		t.Fatalf("GET branches = %+v", branches)
+	}
+
+	fa.set("/src/api", domain.Result{RepoPath: "/src/api", Branch: "main", Status: domain.StatusSynced})
+	resp, err := http.Post(ts.URL+"/repos/"+id+"/refresh", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	getJSON(t, ts.URL+"/repos/"+id+"/status", http.StatusOK, &result)
+	if result.Status != domain.StatusSynced {
+		t.Fatalf("status after refresh = %s, want SYNCED", result.Status)
+	}
+
+	var apiErr map[string]string
+	getJSON(t, ts.URL+"/repos/unknown/status", http.StatusNotFound, &apiErr)
+	if !strings.Contains(apiErr["error"], "unknown") {
+		t.Fatalf("error body = %v", apiErr)
+	}
+}
+
+func TestServerStreamsStatusChanges(t *testing.T) {
+	t.Parallel()
+
+	fa := &fakeAnalyzer{results: map[string]domain.Result{
+		"/src/api": {RepoPath: "/src/api", Branch: "main", Status: domain.StatusSynced},
+	}}
+	srv := NewServer(fa, []string{"/src/api"})
+	srv.RefreshAll(context.Background())
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/events", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+	reader := bufio.NewReader(resp.Body)
+	if line, _ := reader.ReadString('\n'); line != ": connected\n" {
+		t.Fatalf("first line = %q", line)
+	}
+
+	srv.RefreshAll(context.Background())
+	fa.set("/src/api", domain.Result{RepoPath: "/src/api", Branch: "main", Status: domain.StatusDiverged, Ahead: 1, Behind: 1})
+	srv.RefreshAll(context.Background())
+
+	var event, data string
+	for data == "" {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("reading events: %v", err)
+		}
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event: "))
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+	var payload struct {
+		Repo   Repo
+		Result domain.Result
+	}
+	if err := json.Unmarshal([]byte(data), &payload); err != nil {
+		t.Fatalf("event data unreadable: %v", err)
+	}
+	if event != "status" || payload.Repo.ID != RepoID("/src/api") || payload.Result.Status != domain.StatusDiverged {
+		t.Fatalf("event %q payload %+v, want DIVERGED status event (unchanged refresh must not publish)", event, payload)
+	}
+}
+
+type slowAnalyzer struct {
+	fakeAnalyzer
+	inFlight, maxInFlight, calls atomic.Int32
+}
+
+func (f *slowAnalyzer) Analyze(ctx context.Context, repoPath string) domain.Result {
+	n := f.inFlight.Add(1)
+	defer f.inFlight.Add(-1)
+	for {
+		max := f.maxInFlight.Load()
+		if n <= max || f.maxInFlight.CompareAndSwap(max, n) {
+			break
+		}
+	}
+	f.calls.Add(1)
+	time.Sleep(20 * time.Millisecond)
+	return f.fakeAnalyzer.Analyze(ctx, repoPath)
+}
+
+func TestServerSerializesRefreshesOfOneRepo(t *testing.T) {
+	t.Parallel()
+
+	fa := &slowAnalyzer{fakeAnalyzer: fakeAnalyzer{results: map[string]domain.Result{
+		"/src/api": {RepoPath: "/src/api", Branch: "main", Status: domain.StatusSynced},
+	}}}
+	srv := NewServer(fa, []string{"/src/api"})
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			srv.RefreshAll(context.Background())
+		}()
+		go func() {
+			defer wg.Done()
+			resp, err := http.Post(ts.URL+"/repos/"+RepoID("/src/api")+"/refresh", "", nil)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+	if got := fa.maxInFlight.Load(); got != 1 {
+		t.Fatalf("%d concurrent analyses of one repository, want 1", got)
+	}
+	if got := fa.calls.Load(); got >= 8 {
+		t.Errorf("%d analyses for 8 refreshes, want waiting refreshes to reuse newer results", got)
+	}
+}
+
+func TestServerKeepsNewerResult(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 4, 12, 0, 0, 0, time.UTC)
+	fa := &fakeAnalyzer{results: map[string]domain.Result{
+		"/src/api": {RepoPath: "/src/api", Branch: "main", Status: domain.StatusLate, Behind: 1},
+	}}
+	srv := NewServer(fa, []string{"/src/api"})
+	srv.now = func() time.Time { return now }
+	id := RepoID("/src/api")
+	srv.refresh(context.Background(), id)
+
+	state := srv.repos[id]
+	state.result = &domain.Result{RepoPath: "/src/api", Branch: "main", Status: domain.StatusSynced}
+	state.startedAt = now.Add(time.Minute)
+	if result, _ := srv.refresh(context.Background(), id); result.Status != domain.StatusSynced {
+		t.Fatalf("refresh replaced a newer result with %s", result.Status)
+	}
+}
+
+func TestServerAllowOrigin(t *testing.T) {
+	t.Parallel()
+
+	srv := NewServer(&fakeAnalyzer{results: map[string]domain.Result{}}, nil)
+	srv.AllowOrigin = "http://localhost:3000"
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodOptions, "/repos", nil))
+	if rec.Code != http.StatusNoContent || rec.Header().Get("Access-Control-Allow-Origin") != "http://localhost:3000" {
+		t.Fatalf("preflight = %d %v", rec.Code, rec.Header())
+	}
+}
+
+func getJSON(t *testing.T, url string, wantCode int, v any) {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != wantCode {
+		t.Fatalf("GET %s = %d, want %d", url, resp.StatusCode, wantCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		t.Fatalf("GET %s: %v", url, err)
+	}
+}
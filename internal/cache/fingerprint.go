@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/guionardo/git_sync_status/internal/gitdir"
+)
+
+func Fingerprint(repo gitdir.Repo) (string, error) {
+	h := sha256.New()
+
+	head, err := os.ReadFile(filepath.Join(repo.GitDir, "HEAD"))
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(h, "HEAD %s\n", head)
+
+	writeStat(h, "index", filepath.Join(repo.GitDir, "index"))
+	writeStat(h, "packed-refs", filepath.Join(repo.CommonDir, "packed-refs"))
+	writeStat(h, "config", filepath.Join(repo.CommonDir, "config"))
+
+	refs := filepath.Join(repo.CommonDir, "refs")
+	err = filepath.WalkDir(refs, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, _ := filepath.Rel(refs, path)
+		writeStat(h, rel, path)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func writeStat(h hash.Hash, name string, path string) {
+	info, err := os.Stat(path)
+	if err != nil {
+		fmt.Fprintf(h, "%s -\n", name)
+		return
+	}
+	fmt.Fprintf(h, "%s %d %d\n", name, info.Size(), info.ModTime().UnixNano())
+}
@@ -0,0 +1,139 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+	"github.com/guionardo/git_sync_status/internal/gitdir"
+)
+
+const (
+	schemaVersion = 1
+	DefaultMaxAge = 10 * time.Minute
+)
+
+type Store struct {
+	dir    string
+	maxAge time.Duration
+	now    func() time.Time
+}
+
+type entry struct {
+	Version     int
+	RepoPath    string
+	Variant     string
+	Fingerprint string
+	StoredAt    time.Time
+	Result      domain.Result
+}
+
+func DefaultDir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "git-sync-status", "results"), nil
+}
+
+func NewStore(dir string, maxAge time.Duration) *Store {
+	if maxAge <= 0 {
+		maxAge = DefaultMaxAge
+	}
+	return &Store{dir: dir, maxAge: maxAge, now: time.Now}
+}
+
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+func (s *Store) Get(repoPath string, variant string) (domain.Result, bool) {
+	repo, err := gitdir.Find(repoPath)
+	if err != nil {
+		return domain.Result{}, false
+	}
+	fingerprint, err := Fingerprint(repo)
+	if err != nil {
+		return domain.Result{}, false
+	}
+
+	body, err := os.ReadFile(s.path(repoPath, variant))
+	if err != nil {
+		return domain.Result{}, false
+	}
+	var e entry
+	if err := json.Unmarshal(body, &e); err != nil {
+		return domain.Result{}, false
+	}
+	if e.Version != schemaVersion || e.Fingerprint != fingerprint || s.now().Sub(e.StoredAt) > s.maxAge {
+		return domain.Result{}, false
+	}
+	return e.Result, true
+}
+
+func (s *Store) Put(repoPath string, variant string, result domain.Result) error {
+	repo, err := gitdir.Find(repoPath)
+	if err != nil {
+		return err
+	}
+	fingerprint, err := Fingerprint(repo)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(entry{
+		Version:     schemaVersion,
+		RepoPath:    repoPath,
+		Variant:     variant,
+		Fingerprint: fingerprint,
+		StoredAt:    s.now(),
+		Result:      result,
+	})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, ".entry-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(body); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), s.path(repoPath, variant)); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+func (s *Store) Clear() error {
+	err := os.RemoveAll(s.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (s *Store) path(repoPath string, variant string) string {
+	abs, err := filepath.Abs(repoPath)
+	if err != nil {
+		abs = repoPath
+	}
+	sum := sha256.Sum256([]byte(abs + "\x00" + variant))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:])+".json")
+}
@@ -0,0 +1,117 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+)
+
+func TestStoreInvalidatesOnRepositoryChanges(t *testing.T) {
+	t.Parallel()
+
+	repo := fakeRepo(t)
+	store := NewStore(filepath.Join(t.TempDir(), "results"), time.Hour)
+	result := domain.Result{RepoPath: repo, Branch: "main", Status: domain.StatusSynced}
+
+	if _, ok := store.Get(repo, "origin"); ok {
+		t.Fatal("expected miss on empty cache")
+	}
+	if err := store.Put(repo, "origin", result); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	got, ok := store.Get(repo, "origin")
+	if !ok || got.Branch != "main" || got.Status != domain.StatusSynced {
+		t.Fatalf("Get() = %+v, %v", got, ok)
+	}
+	if _, ok := store.Get(repo, "upstream"); ok {
+		t.Fatal("expected miss for another variant")
+	}
+
+	touch(t, filepath.Join(repo, ".git", "refs", "heads", "main"), "2222222\n")
+	if _, ok := store.Get(repo, "origin"); ok {
+		t.Fatal("expected miss after a ref changed")
+	}
+
+	if err := store.Put(repo, "origin", result); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	touch(t, filepath.Join(repo, ".git", "index"), "index v2 updated")
+	if _, ok := store.Get(repo, "origin"); ok {
+		t.Fatal("expected miss after the index changed")
+	}
+
+	if err := store.Put(repo, "origin", result); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	store.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	if _, ok := store.Get(repo, "origin"); ok {
+		t.Fatal("expected miss for an expired entry")
+	}
+}
+
+func TestStoreConcurrentAccessAndClear(t *testing.T) {
+	t.Parallel()
+
+	repo := fakeRepo(t)
+	store := NewStore(filepath.Join(t.TempDir(), "results"), time.Hour)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				if err := store.Put(repo, "origin", domain.Result{RepoPath: repo, Ahead: i}); err != nil {
+					t.Errorf("Put() error = %v", err)
+					return
+				}
+				if got, ok := store.Get(repo, "origin"); ok && got.RepoPath != repo {
+					t.Errorf("Get() returned a corrupted entry: %+v", got)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	entries, err := os.ReadDir(store.Dir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("got %d cache files, want 1 (no leftover temp files)", len(entries))
+	}
+
+	if err := store.Clear(); err != nil {
+		t.Fatalf("Clear() error = %v", err)
+	}
+	if _, ok := store.Get(repo, "origin"); ok {
+		t.Fatal("expected miss after Clear")
+	}
+	if err := store.Clear(); err != nil {
+		t.Fatalf("second Clear() error = %v", err)
+	}
+}
+
+func fakeRepo(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	touch(t, filepath.Join(root, ".git", "HEAD"), "ref: refs/heads/main\n")
+	touch(t, filepath.Join(root, ".git", "refs", "heads", "main"), "1111\n")
+	touch(t, filepath.Join(root, ".git", "index"), "index v1")
+	return root
+}
+
+func touch(t *testing.T, path string, body string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -14,6 +14,102 @@ type Result struct {
 	NOUpstreamWasMerged  bool
 	NOUpstreamMergeBase  string
 	NOUpstreamSuggestion string
+	Conflicts            []ConflictPrediction
+	Submodules           []SubmoduleStatus
+	Worktrees            []WorktreeStatus
+	LFS                  *LFSStatus
+	Tags                 *TagStatus
+	Detached             *DetachedHeadStatus
+	Policy               *PolicyStatus
+	Findings             []RiskFinding
+}
+
+type RiskFinding struct {
+	Rule   string
+	Commit string
+	Path   string
+	Detail string
+}
+
+type PolicyStatus struct {
+	CheckedCommits       int
+	UnsignedCommits      []string
+	ForeignAuthorCommits []string
+}
+
+type DetachedHeadStatus struct {
+	Commit         string
+	LocalBranches  []string
+	RemoteBranches []string
+	Tags           []string
+	OnRemote       bool
+}
+
+type TagStatus struct {
+	LocalOnly  []string
+	RemoteOnly []string
+	Mismatched []TagMismatch
+}
+
+type TagMismatch struct {
+	Name         string
+	LocalCommit  string
+	RemoteCommit string
+}
+
+type LFSStatus struct {
+	TrackedFiles    int
+	LocalObjects    int
+	UnpushedObjects int
+	UnpushedBytes   int64
+	UnpushedPaths   []string
+	Unknown         bool
+}
+
+type WorktreeStatus struct {
+	Path           string
+	Branch         string
+	Head           string
+	Current        bool
+	Detached       bool
+	Dirty          bool
+	Locked         bool
+	LockReason     string
+	Prunable       bool
+	PrunableReason string
+}
+
+type ConflictPrediction struct {
+	Against string
+	Files   []string
+}
+
+type SubmoduleStatus struct {
+	Path             string
+	RecordedCommit   string
+	CheckedOutCommit string
+	Initialized      bool
+	AtRecordedCommit bool
+	Dirty            bool
+	Branch           string
+	Upstream         string
+	Status           Status
+	Behind           int
+	Ahead            int
+	Flags            []string
+	Submodules       []SubmoduleStatus
+}
+
+func (s SubmoduleStatus) InSync() bool {
+	if !s.Initialized || !s.AtRecordedCommit || s.Dirty {
+		return false
+	}
+	for _, child := range s.Submodules {
+		if !child.InSync() {
+			return false
+		}
+	}
+	return true
 }
 
 func (r Result) HasFlag(flag string) bool {
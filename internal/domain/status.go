@@ -6,8 +6,28 @@ const (
 	StatusNotAGitRepo Status = "NOT_A_GIT_REPO"
 	StatusNoRemote    Status = "NO_REMOTE"
 	StatusNoUpstream  Status = "NO_UPSTREAM"
+	StatusDetached    Status = "DETACHED"
 	StatusSynced      Status = "SYNCED"
 	StatusSyncPending Status = "SYNC_PENDING"
 	StatusLate        Status = "LATE"
 	StatusDiverged    Status = "DIVERGED"
 )
+
+const (
+	SeverityOK    = "ok"
+	SeverityWarn  = "warn"
+	SeverityError = "error"
+)
+
+func (s Status) Severity() string {
+	switch s {
+	case StatusSynced:
+		return SeverityOK
+	case StatusSyncPending, StatusLate, StatusNoUpstream, StatusDetached:
+		return SeverityWarn
+	case StatusDiverged, StatusNoRemote, StatusNotAGitRepo:
+		return SeverityError
+	default:
+		return ""
+	}
+}
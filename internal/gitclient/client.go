@@ -1,6 +1,76 @@
 package gitclient
 
-import "context"
+import (
+	"context"
+	"time"
+)
+
+type RefInfo struct {
+	Name        string
+	Commit      string
+	AuthorName  string
+	AuthorEmail string
+	CommitDate  time.Time
+}
+
+type Submodule struct {
+	Path             string
+	RecordedCommit   string
+	CheckedOutCommit string
+	Initialized      bool
+	Conflicted       bool
+}
+
+func (s Submodule) AtRecordedCommit() bool {
+	return s.Initialized && s.CheckedOutCommit == s.RecordedCommit
+}
+
+type Worktree struct {
+	Path           string
+	Head           string
+	Branch         string
+	Bare           bool
+	Detached       bool
+	Locked         bool
+	LockReason     string
+	Prunable       bool
+	PrunableReason string
+}
+
+type LFSPointer struct {
+	Path  string
+	OID   string
+	Size  int64
+	Local bool
+}
+
+type Commit struct {
+	SHA         string
+	AuthorName  string
+	AuthorEmail string
+	Date        time.Time
+	Subject     string
+}
+
+type CommitSignature struct {
+	SHA            string
+	Status         string
+	AuthorEmail    string
+	CommitterEmail string
+}
+
+type OutgoingFile struct {
+	Commit string
+	Path   string
+	Blob   string
+	Size   int64
+}
+
+type FilePatch struct {
+	Commit string
+	Path   string
+	Added  []string
+}
 
 type Client interface {
 	IsGitRepo(ctx context.Context, path string) (bool, error)
@@ -17,4 +87,26 @@ type Client interface {
 	DefaultBranch(ctx context.Context, path string, remote string) (string, error)
 	IsBranchMergedInto(ctx context.Context, path string, branch string, base string) (bool, error)
 	LocalBranches(ctx context.Context, path string) ([]string, error)
+	RemoteBranches(ctx context.Context, path string, remote string) ([]RefInfo, error)
+	IsAncestor(ctx context.Context, path string, ancestor string, descendant string) (bool, error)
+	CommitInfo(ctx context.Context, path string, ref string) (RefInfo, error)
+	BranchCreatedAt(ctx context.Context, path string, branch string) (created time.Time, recorded bool, err error)
+	PredictMergeConflicts(ctx context.Context, path string, ours string, theirs string) ([]string, error)
+	Submodules(ctx context.Context, path string) ([]Submodule, error)
+	TopLevel(ctx context.Context, path string) (string, error)
+	Worktrees(ctx context.Context, path string) ([]Worktree, error)
+	LFSPointers(ctx context.Context, path string) ([]LFSPointer, error)
+	LFSEndpoint(ctx context.Context, path string, remote string) (LFSEndpoint, error)
+	CredentialFill(ctx context.Context, path string, url string) (Credential, error)
+	LocalTags(ctx context.Context, path string) (map[string]string, error)
+	RemoteTags(ctx context.Context, path string, remote string) (map[string]string, error)
+	ReflogCommits(ctx context.Context, path string, ref string) ([]string, error)
+	CommitsNotIn(ctx context.Context, path string, tips []string, excludes []string) ([]Commit, error)
+	RefsContaining(ctx context.Context, path string, commit string) ([]string, error)
+	TagsPointingAt(ctx context.Context, path string, commit string) ([]string, error)
+	ConfigValue(ctx context.Context, path string, key string) (string, error)
+	CommitSignatures(ctx context.Context, path string, revRange string) ([]CommitSignature, error)
+	OutgoingFiles(ctx context.Context, path string, revs []string) ([]OutgoingFile, error)
+	OutgoingPatches(ctx context.Context, path string, revs []string) ([]FilePatch, error)
+	HooksDir(ctx context.Context, path string) (string, error)
 }
@@ -0,0 +1,112 @@
+package gitclient
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const commitFormat = "--format=%H%x00%an%x00%ae%x00%ct%x00%s"
+
+func (c *ShellClient) ReflogCommits(ctx context.Context, path string, ref string) ([]string, error) {
+	if _, err := c.runGit(ctx, path, "rev-parse", "--verify", "--quiet", ref); err != nil {
+		return nil, nil
+	}
+	out, err := c.runGit(ctx, path, "log", "--walk-reflogs", "--format=%H", ref, "--")
+	if err != nil {
+		return nil, err
+	}
+	var commits []string
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || seen[line] {
+			continue
+		}
+		seen[line] = true
+		commits = append(commits, line)
+	}
+	return commits, nil
+}
+
+func (c *ShellClient) CommitsNotIn(ctx context.Context, path string, tips []string, excludes []string) ([]Commit, error) {
+	if len(tips) == 0 {
+		return nil, nil
+	}
+	args := append([]string{"log", commitFormat, "--stdin", "--not"}, excludes...)
+	out, err := c.runGitInput(ctx, path, strings.Join(tips, "\n")+"\n", args...)
+	if err != nil {
+		return nil, err
+	}
+	return parseCommits(out)
+}
+
+func (c *ShellClient) RefsContaining(ctx context.Context, path string, commit string) ([]string, error) {
+	out, err := c.runGit(ctx, path, "for-each-ref", "--contains", commit, "--format=%(refname)", "refs/heads", "refs/remotes", "refs/tags")
+	if err != nil {
+		return nil, err
+	}
+	var refs []string
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || (strings.HasPrefix(line, "refs/remotes/") && strings.HasSuffix(line, "/HEAD")) {
+			continue
+		}
+		refs = append(refs, line)
+	}
+	return refs, nil
+}
+
+func (c *ShellClient) TagsPointingAt(ctx context.Context, path string, commit string) ([]string, error) {
+	out, err := c.runGit(ctx, path, "tag", "--points-at", commit)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(out), nil
+}
+
+func (c *ShellClient) CommitSignatures(ctx context.Context, path string, revRange string) ([]CommitSignature, error) {
+	out, err := c.runGit(ctx, path, "log", "--format=%H%x00%G?%x00%ae%x00%ce", revRange, "--")
+	if err != nil {
+		return nil, err
+	}
+	var sigs []CommitSignature
+	for _, line := range strings.Split(out, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		fields := strings.Split(line, "\x00")
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("invalid signature line %q", line)
+		}
+		sigs = append(sigs, CommitSignature{SHA: fields[0], Status: fields[1], AuthorEmail: fields[2], CommitterEmail: fields[3]})
+	}
+	return sigs, nil
+}
+
+func parseCommits(out string) ([]Commit, error) {
+	var commits []Commit
+	for _, line := range strings.Split(out, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		fields := strings.SplitN(line, "\x00", 5)
+		if len(fields) != 5 {
+			return nil, fmt.Errorf("invalid commit line %q", line)
+		}
+		unix, err := strconv.ParseInt(fields[3], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid commit date %q: %w", fields[3], err)
+		}
+		commits = append(commits, Commit{
+			SHA:         fields[0],
+			AuthorName:  fields[1],
+			AuthorEmail: fields[2],
+			Date:        time.Unix(unix, 0),
+			Subject:     fields[4],
+		})
+	}
+	return commits, nil
+}
@@ -0,0 +1,320 @@
+package gitclient
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	lfsPointerVersion = "version https://git-lfs.github.com/spec/v1"
+	lfsPointerMaxSize = 1024
+)
+
+func (c *ShellClient) LFSPointers(ctx context.Context, path string) ([]LFSPointer, error) {
+	out, err := c.runGit(ctx, path, "ls-files", "-s", "-z", "--", ":(attr:filter=lfs)")
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return nil, nil
+	}
+
+	var paths, blobs []string
+	for _, entry := range strings.Split(strings.TrimRight(out, "\x00"), "\x00") {
+		meta, file, ok := strings.Cut(entry, "\t")
+		fields := strings.Fields(meta)
+		if !ok || len(fields) != 3 {
+			return nil, fmt.Errorf("invalid ls-files entry %q", entry)
+		}
+		paths = append(paths, file)
+		blobs = append(blobs, fields[1])
+	}
+
+	contents, err := c.readSmallBlobs(ctx, path, blobs, lfsPointerMaxSize)
+	if err != nil {
+		return nil, err
+	}
+
+	commonDir, err := c.runGit(ctx, path, "rev-parse", "--git-common-dir")
+	if err != nil {
+		return nil, err
+	}
+	if !filepath.IsAbs(commonDir) {
+		commonDir = filepath.Join(path, commonDir)
+	}
+
+	pointers := make([]LFSPointer, 0, len(paths))
+	for i, file := range paths {
+		oid, size, ok := parseLFSPointer(contents[blobs[i]])
+		if !ok {
+			continue
+		}
+		pointer := LFSPointer{Path: file, OID: oid, Size: size}
+		if _, err := os.Stat(filepath.Join(commonDir, "lfs", "objects", oid[0:2], oid[2:4], oid)); err == nil {
+			pointer.Local = true
+		}
+		pointers = append(pointers, pointer)
+	}
+	return pointers, nil
+}
+
+type LFSEndpoint struct {
+	URL    string
+	Header map[string]string
+}
+
+func (c *ShellClient) LFSEndpoint(ctx context.Context, path string, remote string) (LFSEndpoint, error) {
+	if url := c.configuredLFSURL(ctx, path, remote); url != "" {
+		return LFSEndpoint{URL: url}, nil
+	}
+
+	remoteURL, err := c.runGit(ctx, path, "remote", "get-url", remote)
+	if err != nil {
+		return LFSEndpoint{}, err
+	}
+	url, err := lfsEndpointFromRemoteURL(remoteURL)
+	if err != nil {
+		return LFSEndpoint{}, err
+	}
+	target, ok := parseSSHRemote(remoteURL)
+	if !ok {
+		return LFSEndpoint{URL: url}, nil
+	}
+
+	auth, err := c.sshLFSAuthenticate(ctx, path, target)
+	if err != nil {
+		return LFSEndpoint{}, fmt.Errorf("git-lfs-authenticate on %s failed: %w", target.host, err)
+	}
+	if auth.Href != "" {
+		url = auth.Href
+	}
+	return LFSEndpoint{URL: url, Header: auth.Header}, nil
+}
+
+func (c *ShellClient) configuredLFSURL(ctx context.Context, path string, remote string) string {
+	keys := []string{"lfs.url", fmt.Sprintf("remote.%s.lfsurl", remote)}
+	for _, key := range keys {
+		if out, err := c.runGit(ctx, path, "config", "--get", key); err == nil && out != "" {
+			return out
+		}
+	}
+	top, err := c.runGit(ctx, path, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return ""
+	}
+	for _, key := range keys {
+		if out, err := c.runGit(ctx, path, "config", "--file", filepath.Join(top, ".lfsconfig"), "--get", key); err == nil && out != "" {
+			return out
+		}
+	}
+	return ""
+}
+
+type sshTarget struct {
+	userHost string
+	port     string
+	path     string
+	host     string
+}
+
+type sshLFSAuth struct {
+	Href   string            `json:"href"`
+	Header map[string]string `json:"header"`
+}
+
+func parseSSHRemote(url string) (sshTarget, bool) {
+	url = strings.TrimSpace(url)
+	switch {
+	case strings.HasPrefix(url, "ssh://"):
+		rest := strings.TrimPrefix(url, "ssh://")
+		authority, repoPath, ok := strings.Cut(rest, "/")
+		if !ok {
+			return sshTarget{}, false
+		}
+		target := sshTarget{userHost: authority, path: "/" + repoPath}
+		if i := strings.LastIndex(authority, ":"); i > strings.LastIndex(authority, "@") {
+			target.userHost, target.port = authority[:i], authority[i+1:]
+		}
+		target.host = target.userHost[strings.LastIndex(target.userHost, "@")+1:]
+		return target, true
+	case strings.Contains(url, ":") && !strings.Contains(url, "://"):
+		userHost, repoPath, _ := strings.Cut(url, ":")
+		return sshTarget{userHost: userHost, path: repoPath, host: userHost[strings.LastIndex(userHost, "@")+1:]}, true
+	default:
+		return sshTarget{}, false
+	}
+}
+
+func (c *ShellClient) sshLFSAuthenticate(ctx context.Context, path string, target sshTarget) (sshLFSAuth, error) {
+	sshCommand := os.Getenv("GIT_SSH_COMMAND")
+	if sshCommand == "" {
+		sshCommand, _ = c.runGit(ctx, path, "config", "--get", "core.sshCommand")
+	}
+	if sshCommand == "" {
+		sshCommand = "ssh"
+	}
+
+	args := []string{"-o", "BatchMode=yes"}
+	if target.port != "" {
+		args = append(args, "-p", target.port)
+	}
+	args = append(args, target.userHost, fmt.Sprintf("git-lfs-authenticate '%s' upload", target.path))
+	cmd := exec.CommandContext(ctx, "sh", append([]string{"-c", sshCommand + ` "$@"`, "ssh"}, args...)...)
+	cmd.Dir = path
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return sshLFSAuth{}, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	var auth sshLFSAuth
+	if err := json.Unmarshal(out, &auth); err != nil {
+		return sshLFSAuth{}, fmt.Errorf("invalid git-lfs-authenticate response: %w", err)
+	}
+	return auth, nil
+}
+
+type Credential struct {
+	Username string
+	Password string
+}
+
+func (c *ShellClient) CredentialFill(ctx context.Context, path string, url string) (Credential, error) {
+	var out bytes.Buffer
+	err := c.run(ctx, Command{
+		Dir:    path,
+		Args:   []string{"credential", "fill"},
+		Env:    []string{"GIT_TERMINAL_PROMPT=0", "GIT_ASKPASS=true", "SSH_ASKPASS=true", "GCM_INTERACTIVE=never"},
+		Stdin:  strings.NewReader("url=" + url + "\n\n"),
+		Stdout: &out,
+	})
+	if err != nil {
+		return Credential{}, ctx.Err()
+	}
+
+	var cred Credential
+	for _, line := range strings.Split(out.String(), "\n") {
+		key, value, _ := strings.Cut(line, "=")
+		switch key {
+		case "username":
+			cred.Username = value
+		case "password":
+			cred.Password = value
+		}
+	}
+	if cred.Password == "" {
+		return Credential{}, nil
+	}
+	return cred, nil
+}
+
+func lfsEndpointFromRemoteURL(url string) (string, error) {
+	url = strings.TrimSuffix(strings.TrimSpace(url), "/")
+	switch {
+	case strings.HasPrefix(url, "https://"), strings.HasPrefix(url, "http://"):
+	case strings.HasPrefix(url, "ssh://"):
+		rest := strings.TrimPrefix(url, "ssh://")
+		if at := strings.Index(rest, "@"); at >= 0 {
+			rest = rest[at+1:]
+		}
+		host, repoPath, ok := strings.Cut(rest, "/")
+		if !ok {
+			return "", fmt.Errorf("cannot derive LFS endpoint from %q", url)
+		}
+		host, _, _ = strings.Cut(host, ":")
+		url = "https://" + host + "/" + repoPath
+	case strings.Contains(url, ":") && !strings.Contains(url, "://"):
+		userHost, repoPath, _ := strings.Cut(url, ":")
+		if at := strings.Index(userHost, "@"); at >= 0 {
+			userHost = userHost[at+1:]
+		}
+		url = "https://" + userHost + "/" + strings.TrimPrefix(repoPath, "/")
+	default:
+		return "", fmt.Errorf("cannot derive LFS endpoint from %q", url)
+	}
+	if !strings.HasSuffix(url, ".git") {
+		url += ".git"
+	}
+	return url + "/info/lfs", nil
+}
+
+func parseLFSPointer(content string) (oid string, size int64, ok bool) {
+	lines := strings.Split(strings.TrimSpace(content), "\n")
+	if len(lines) < 3 || strings.TrimSpace(lines[0]) != lfsPointerVersion {
+		return "", 0, false
+	}
+	for _, line := range lines[1:] {
+		key, value, _ := strings.Cut(strings.TrimSpace(line), " ")
+		switch key {
+		case "oid":
+			oid = strings.TrimPrefix(value, "sha256:")
+		case "size":
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return "", 0, false
+			}
+			size = n
+		}
+	}
+	if len(oid) != 64 {
+		return "", 0, false
+	}
+	return oid, size, true
+}
+
+func (c *ShellClient) readSmallBlobs(ctx context.Context, path string, blobs []string, maxSize int) (map[string]string, error) {
+	out, err := c.runGitInput(ctx, path, strings.Join(blobs, "\n")+"\n", "cat-file", "--batch-check")
+	if err != nil {
+		return nil, err
+	}
+	var small []string
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			continue
+		}
+		if size, err := strconv.Atoi(fields[2]); err == nil && size <= maxSize {
+			small = append(small, fields[0])
+		}
+	}
+	if len(small) == 0 {
+		return nil, nil
+	}
+
+	out, err = c.runGitInput(ctx, path, strings.Join(small, "\n")+"\n", "cat-file", "--batch")
+	if err != nil {
+		return nil, err
+	}
+	reader := bufio.NewReader(strings.NewReader(out))
+	contents := make(map[string]string, len(small))
+	for range small {
+		header, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("invalid cat-file output: %w", err)
+		}
+		fields := strings.Fields(header)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("invalid cat-file header %q", header)
+		}
+		size, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cat-file size %q: %w", fields[2], err)
+		}
+		body := make([]byte, size+1)
+		if _, err := io.ReadFull(reader, body); err != nil {
+			return nil, fmt.Errorf("invalid cat-file body: %w", err)
+		}
+		contents[fields[0]] = string(body[:size])
+	}
+	return contents, nil
+}
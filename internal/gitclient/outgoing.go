@@ -0,0 +1,106 @@
+package gitclient
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	commitLinePrefix = "commit "
+	nullBlob         = "0000000000000000000000000000000000000000"
+)
+
+func (c *ShellClient) OutgoingFiles(ctx context.Context, path string, revs []string) ([]OutgoingFile, error) {
+	args := []string{"-c", "core.quotePath=false", "log", "--format=" + commitLinePrefix + "%H", "--raw", "--no-renames", "--no-abbrev"}
+	out, err := c.runGit(ctx, path, append(append(args, revs...), "--")...)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []OutgoingFile
+	commit := ""
+	for _, line := range strings.Split(out, "\n") {
+		switch {
+		case strings.HasPrefix(line, commitLinePrefix):
+			commit = strings.TrimPrefix(line, commitLinePrefix)
+		case strings.HasPrefix(line, ":"):
+			meta, file, ok := strings.Cut(line, "\t")
+			fields := strings.Fields(meta)
+			if !ok || len(fields) != 5 {
+				return nil, fmt.Errorf("invalid raw diff line %q", line)
+			}
+			if fields[4] == "D" || fields[3] == nullBlob {
+				continue
+			}
+			files = append(files, OutgoingFile{Commit: commit, Path: file, Blob: fields[3]})
+		}
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	blobs := make([]string, 0, len(files))
+	for _, f := range files {
+		blobs = append(blobs, f.Blob)
+	}
+	sizes, err := c.runGitInput(ctx, path, strings.Join(blobs, "\n")+"\n", "cat-file", "--batch-check")
+	if err != nil {
+		return nil, err
+	}
+	sizeByBlob := make(map[string]int64, len(blobs))
+	for _, line := range strings.Split(strings.TrimSpace(sizes), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			continue
+		}
+		if size, err := strconv.ParseInt(fields[2], 10, 64); err == nil {
+			sizeByBlob[fields[0]] = size
+		}
+	}
+	for i := range files {
+		files[i].Size = sizeByBlob[files[i].Blob]
+	}
+	return files, nil
+}
+
+func (c *ShellClient) OutgoingPatches(ctx context.Context, path string, revs []string) ([]FilePatch, error) {
+	args := []string{"-c", "core.quotePath=false", "log", "--format=" + commitLinePrefix + "%H", "--patch", "--unified=0",
+		"--no-color", "--no-ext-diff", "--no-renames"}
+	out, err := c.runGit(ctx, path, append(append(args, revs...), "--")...)
+	if err != nil {
+		return nil, err
+	}
+	return parsePatches(out), nil
+}
+
+func parsePatches(out string) []FilePatch {
+	var patches []FilePatch
+	var current *FilePatch
+	commit := ""
+	inHeader := false
+	for _, line := range strings.Split(out, "\n") {
+		switch {
+		case strings.HasPrefix(line, commitLinePrefix):
+			commit = strings.TrimPrefix(line, commitLinePrefix)
+			current = nil
+		case strings.HasPrefix(line, "diff --git "):
+			inHeader = true
+			current = nil
+		case inHeader && strings.HasPrefix(line, "+++ "):
+			inHeader = false
+			target := strings.TrimPrefix(line, "+++ ")
+			if target == "/dev/null" {
+				continue
+			}
+			patches = append(patches, FilePatch{Commit: commit, Path: strings.TrimPrefix(target, "b/")})
+			current = &patches[len(patches)-1]
+		case inHeader && strings.HasPrefix(line, "Binary files "):
+			inHeader = false
+		case current != nil && strings.HasPrefix(line, "+"):
+			current.Added = append(current.Added, strings.TrimPrefix(line, "+"))
+		}
+	}
+	return patches
+}
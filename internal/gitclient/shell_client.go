@@ -1,17 +1,30 @@
 package gitclient
 
 import (
+	"bytes"
 	"context"
 	"fmt"
-	"os/exec"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
-type ShellClient struct{}
+const refInfoFormat = "%(refname)%00%(objectname)%00%(authorname)%00%(authoremail:trim)%00%(committerdate:unix)"
+
+type ShellClient struct {
+	runner Runner
+
+	versionOnce sync.Once
+	mergeTree   bool
+}
 
 func NewShellClient() *ShellClient {
-	return &ShellClient{}
+	return &ShellClient{runner: ExecRunner{}}
+}
+
+func NewShellClientWithRunner(runner Runner) *ShellClient {
+	return &ShellClient{runner: runner}
 }
 
 func (c *ShellClient) IsGitRepo(ctx context.Context, path string) (bool, error) {
@@ -115,7 +128,7 @@ func parseAheadBehind(out string) (behind int, ahead int, err error) {
 }
 
 func (c *ShellClient) IsWorktreeDirty(ctx context.Context, path string) (bool, error) {
-	out, err := c.runGit(ctx, path, "status", "--porcelain")
+	out, err := c.runGit(ctx, path, "--no-optional-locks", "status", "--porcelain")
 	if err != nil {
 		return false, err
 	}
@@ -167,12 +180,346 @@ func (c *ShellClient) LocalBranches(ctx context.Context, path string) ([]string,
 	return branches, nil
 }
 
+func (c *ShellClient) RemoteBranches(ctx context.Context, path string, remote string) ([]RefInfo, error) {
+	prefix := fmt.Sprintf("refs/remotes/%s/", remote)
+	out, err := c.runGit(ctx, path, "for-each-ref", "--format="+refInfoFormat, strings.TrimSuffix(prefix, "/"))
+	if err != nil {
+		return nil, err
+	}
+	refs, err := parseRefInfos(out)
+	if err != nil {
+		return nil, err
+	}
+	branches := make([]RefInfo, 0, len(refs))
+	for _, ref := range refs {
+		name := strings.TrimPrefix(ref.Name, prefix)
+		if name == "HEAD" {
+			continue
+		}
+		ref.Name = remote + "/" + name
+		branches = append(branches, ref)
+	}
+	return branches, nil
+}
+
+func (c *ShellClient) IsAncestor(ctx context.Context, path string, ancestor string, descendant string) (bool, error) {
+	_, err := c.runGit(ctx, path, "merge-base", "--is-ancestor", ancestor, descendant)
+	if err == nil {
+		return true, nil
+	}
+	if code, ok := exitCode(err); ok && code == 1 {
+		return false, nil
+	}
+	return false, err
+}
+
+func (c *ShellClient) CommitInfo(ctx context.Context, path string, ref string) (RefInfo, error) {
+	out, err := c.runGit(ctx, path, "log", "-1", "--format=%x00%H%x00%an%x00%ae%x00%ct", ref, "--")
+	if err != nil {
+		return RefInfo{}, err
+	}
+	refs, err := parseRefInfos(out)
+	if err != nil {
+		return RefInfo{}, err
+	}
+	if len(refs) == 0 {
+		return RefInfo{}, fmt.Errorf("no commit found for %q", ref)
+	}
+	info := refs[0]
+	info.Name = ref
+	return info, nil
+}
+
+func (c *ShellClient) BranchCreatedAt(ctx context.Context, path string, branch string) (time.Time, bool, error) {
+	out, err := c.runGit(ctx, path, "log", "--walk-reflogs", "--date=unix", "--format=%gd%x00%gs", "refs/heads/"+branch, "--")
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	selector, message, _ := strings.Cut(strings.TrimSpace(lines[len(lines)-1]), "\x00")
+	if selector == "" {
+		return time.Time{}, false, nil
+	}
+	start := strings.LastIndex(selector, "@{")
+	if start < 0 || !strings.HasSuffix(selector, "}") {
+		return time.Time{}, false, fmt.Errorf("invalid reflog selector %q", selector)
+	}
+	unix, err := strconv.ParseInt(selector[start+2:len(selector)-1], 10, 64)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid reflog date %q: %w", selector, err)
+	}
+	return time.Unix(unix, 0), recordsCreation(message), nil
+}
+
+func recordsCreation(reflogMessage string) bool {
+	for _, prefix := range []string{"branch: Created from", "commit (initial):", "clone: from"} {
+		if strings.HasPrefix(reflogMessage, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *ShellClient) PredictMergeConflicts(ctx context.Context, path string, ours string, theirs string) ([]string, error) {
+	if !c.supportsMergeTreeWriteTree(ctx, path) {
+		return nil, nil
+	}
+	out, code, err := c.runGitWithExitCode(ctx, path, "merge-tree", "--write-tree", "--name-only", "--no-messages", ours, theirs)
+	if err != nil {
+		return nil, err
+	}
+	switch code {
+	case 0:
+		return nil, nil
+	case 1:
+		lines := strings.Split(out, "\n")
+		files := make([]string, 0, len(lines))
+		seen := make(map[string]bool, len(lines))
+		for _, line := range lines[1:] {
+			line = strings.TrimSpace(line)
+			if line == "" || seen[line] {
+				continue
+			}
+			seen[line] = true
+			files = append(files, line)
+		}
+		return files, nil
+	default:
+		return nil, fmt.Errorf("git merge-tree failed with exit code %d: %s", code, out)
+	}
+}
+
+func (c *ShellClient) supportsMergeTreeWriteTree(ctx context.Context, path string) bool {
+	c.versionOnce.Do(func() {
+		c.mergeTree = true
+		out, err := c.runGit(ctx, path, "version")
+		if err != nil {
+			return
+		}
+		if major, minor, ok := parseGitVersion(out); ok {
+			c.mergeTree = major > 2 || major == 2 && minor >= 38
+		}
+	})
+	return c.mergeTree
+}
+
+func parseGitVersion(out string) (major int, minor int, ok bool) {
+	fields := strings.Fields(out)
+	if len(fields) < 3 || fields[0] != "git" || fields[1] != "version" {
+		return 0, 0, false
+	}
+	parts := strings.SplitN(fields[2], ".", 3)
+	if len(parts) < 2 {
+		return 0, 0, false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	minor, err = strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, false
+	}
+	return major, minor, true
+}
+
+func (c *ShellClient) Submodules(ctx context.Context, path string) ([]Submodule, error) {
+	out, err := c.runGitUntrimmed(ctx, path, "submodule", "status")
+	if err != nil {
+		return nil, err
+	}
+	submodules, err := parseSubmoduleStatus(out)
+	if err != nil {
+		return nil, err
+	}
+	for i, sm := range submodules {
+		if sm.Initialized && sm.RecordedCommit == "" {
+			recorded, err := c.runGit(ctx, path, "rev-parse", ":"+sm.Path)
+			if err != nil {
+				return nil, err
+			}
+			submodules[i].RecordedCommit = strings.TrimSpace(recorded)
+		}
+	}
+	return submodules, nil
+}
+
+func parseSubmoduleStatus(out string) ([]Submodule, error) {
+	var submodules []Submodule
+	for _, line := range strings.Split(out, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		commit, rest, ok := strings.Cut(line[1:], " ")
+		if !ok || rest == "" {
+			return nil, fmt.Errorf("invalid submodule status line %q", line)
+		}
+		if idx := strings.LastIndex(rest, " ("); idx >= 0 && strings.HasSuffix(rest, ")") {
+			rest = rest[:idx]
+		}
+		sm := Submodule{Path: rest}
+		switch line[0] {
+		case '-':
+			sm.RecordedCommit = commit
+		case '+':
+			sm.Initialized = true
+			sm.CheckedOutCommit = commit
+		case 'U':
+			sm.Initialized = true
+			sm.Conflicted = true
+			sm.CheckedOutCommit = commit
+		case ' ':
+			sm.Initialized = true
+			sm.CheckedOutCommit = commit
+			sm.RecordedCommit = commit
+		default:
+			return nil, fmt.Errorf("invalid submodule status line %q", line)
+		}
+		submodules = append(submodules, sm)
+	}
+	return submodules, nil
+}
+
+func (c *ShellClient) ConfigValue(ctx context.Context, path string, key string) (string, error) {
+	out, code, err := c.runGitWithExitCode(ctx, path, "config", "--get", key)
+	if err != nil {
+		return "", err
+	}
+	switch code {
+	case 0:
+		return out, nil
+	case 1:
+		return "", nil
+	default:
+		return "", fmt.Errorf("git config --get %s failed: %s", key, out)
+	}
+}
+
+func (c *ShellClient) HooksDir(ctx context.Context, path string) (string, error) {
+	out, err := c.runGit(ctx, path, "rev-parse", "--path-format=absolute", "--git-path", "hooks")
+	if err != nil {
+		return "", err
+	}
+	return out, nil
+}
+
+func (c *ShellClient) TopLevel(ctx context.Context, path string) (string, error) {
+	return c.runGit(ctx, path, "rev-parse", "--show-toplevel")
+}
+
+func (c *ShellClient) Worktrees(ctx context.Context, path string) ([]Worktree, error) {
+	out, err := c.runGit(ctx, path, "worktree", "list", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+	return parseWorktreeList(out), nil
+}
+
+func parseWorktreeList(out string) []Worktree {
+	var worktrees []Worktree
+	var current *Worktree
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if line == "" {
+			current = nil
+			continue
+		}
+		key, value, _ := strings.Cut(line, " ")
+		if key == "worktree" {
+			worktrees = append(worktrees, Worktree{Path: value})
+			current = &worktrees[len(worktrees)-1]
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		switch key {
+		case "HEAD":
+			current.Head = value
+		case "branch":
+			current.Branch = strings.TrimPrefix(value, "refs/heads/")
+		case "bare":
+			current.Bare = true
+		case "detached":
+			current.Detached = true
+		case "locked":
+			current.Locked = true
+			current.LockReason = value
+		case "prunable":
+			current.Prunable = true
+			current.PrunableReason = value
+		}
+	}
+	return worktrees
+}
+
+func parseRefInfos(out string) ([]RefInfo, error) {
+	var refs []RefInfo
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		fields := strings.Split(line, "\x00")
+		if len(fields) != 5 {
+			return nil, fmt.Errorf("invalid ref output %q", line)
+		}
+		ref := RefInfo{
+			Name:        fields[0],
+			Commit:      fields[1],
+			AuthorName:  fields[2],
+			AuthorEmail: fields[3],
+		}
+		if fields[4] != "" {
+			unix, err := strconv.ParseInt(fields[4], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid commit date %q: %w", fields[4], err)
+			}
+			ref.CommitDate = time.Unix(unix, 0)
+		}
+		refs = append(refs, ref)
+	}
+	return refs, nil
+}
+
 func (c *ShellClient) runGit(ctx context.Context, path string, args ...string) (string, error) {
-	cmd := exec.CommandContext(ctx, "git", args...)
-	cmd.Dir = path
-	out, err := cmd.CombinedOutput()
+	out, err := c.runGitUntrimmed(ctx, path, args...)
+	return strings.TrimSpace(out), err
+}
+
+func (c *ShellClient) runGitUntrimmed(ctx context.Context, path string, args ...string) (string, error) {
+	var out bytes.Buffer
+	err := c.run(ctx, Command{Dir: path, Args: args, Stdout: &out, Stderr: &out})
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(out.String()))
+	}
+	return out.String(), nil
+}
+
+func (c *ShellClient) runGitInput(ctx context.Context, path string, input string, args ...string) (string, error) {
+	var out, stderr bytes.Buffer
+	err := c.run(ctx, Command{Dir: path, Args: args, Stdin: strings.NewReader(input), Stdout: &out, Stderr: &stderr})
 	if err != nil {
-		return "", fmt.Errorf("git %s failed: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+		return "", fmt.Errorf("git %s failed: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return out.String(), nil
+}
+
+func (c *ShellClient) runGitWithExitCode(ctx context.Context, path string, args ...string) (string, int, error) {
+	var out bytes.Buffer
+	err := c.run(ctx, Command{Dir: path, Args: args, Stdout: &out, Stderr: &out})
+	if err != nil {
+		if code, ok := exitCode(err); ok {
+			return strings.TrimSpace(out.String()), code, nil
+		}
+		return "", 0, fmt.Errorf("git %s failed: %w", strings.Join(args, " "), err)
+	}
+	return strings.TrimSpace(out.String()), 0, nil
+}
+
+func (c *ShellClient) run(ctx context.Context, cmd Command) error {
+	if c.runner == nil {
+		return ExecRunner{}.Run(ctx, cmd)
 	}
-	return strings.TrimSpace(string(out)), nil
+	return c.runner.Run(ctx, cmd)
 }
@@ -0,0 +1,59 @@
+package gitclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+type scriptedRunner struct {
+	version string
+	calls   []string
+}
+
+func (r *scriptedRunner) Run(_ context.Context, cmd Command) error {
+	r.calls = append(r.calls, strings.Join(cmd.Args, " "))
+	if len(cmd.Args) > 0 && cmd.Args[0] == "version" {
+		_, _ = io.WriteString(cmd.Stdout, r.version+"\n")
+		return nil
+	}
+	return fmt.Errorf("unexpected git %s", strings.Join(cmd.Args, " "))
+}
+
+func TestPredictMergeConflictsSkipsOldGit(t *testing.T) {
+	t.Parallel()
+
+	runner := &scriptedRunner{version: "git version 2.37.1 (Apple Git-137.1)"}
+	client := NewShellClientWithRunner(runner)
+	for range 2 {
+		files, err := client.PredictMergeConflicts(context.Background(), "/repo", "HEAD", "origin/main")
+		if err != nil || files != nil {
+			t.Fatalf("PredictMergeConflicts() = %v, %v, want nothing on git 2.37", files, err)
+		}
+	}
+	if len(runner.calls) != 1 || runner.calls[0] != "version" {
+		t.Fatalf("git calls = %q, want a single version check", runner.calls)
+	}
+}
+
+func TestParseGitVersion(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		out          string
+		major, minor int
+		ok           bool
+	}{
+		{"git version 2.39.5", 2, 39, true},
+		{"git version 2.40.0.windows.1", 2, 40, true},
+		{"git version 2.37.1 (Apple Git-137.1)", 2, 37, true},
+		{"not git", 0, 0, false},
+	} {
+		major, minor, ok := parseGitVersion(tc.out)
+		if major != tc.major || minor != tc.minor || ok != tc.ok {
+			t.Errorf("parseGitVersion(%q) = %d, %d, %v", tc.out, major, minor, ok)
+		}
+	}
+}
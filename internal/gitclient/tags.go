@@ -0,0 +1,58 @@
+package gitclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+func (c *ShellClient) LocalTags(ctx context.Context, path string) (map[string]string, error) {
+	out, err := c.runGit(ctx, path, "for-each-ref", "--format=%(refname:strip=2) %(objectname) %(*objectname)", "refs/tags")
+	if err != nil {
+		return nil, err
+	}
+	tags := make(map[string]string)
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		switch len(fields) {
+		case 0:
+			continue
+		case 2:
+			tags[fields[0]] = fields[1]
+		case 3:
+			tags[fields[0]] = fields[2]
+		default:
+			return nil, fmt.Errorf("invalid tag line %q", line)
+		}
+	}
+	return tags, nil
+}
+
+func (c *ShellClient) RemoteTags(ctx context.Context, path string, remote string) (map[string]string, error) {
+	out, err := c.runGit(ctx, path, "ls-remote", "--tags", remote)
+	if err != nil {
+		return nil, err
+	}
+	return parseRemoteTags(out), nil
+}
+
+func parseRemoteTags(out string) map[string]string {
+	tags := make(map[string]string)
+	peeled := make(map[string]string)
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || !strings.HasPrefix(fields[1], "refs/tags/") {
+			continue
+		}
+		name := strings.TrimPrefix(fields[1], "refs/tags/")
+		if base, ok := strings.CutSuffix(name, "^{}"); ok {
+			peeled[base] = fields[0]
+			continue
+		}
+		tags[name] = fields[0]
+	}
+	for name, commit := range peeled {
+		tags[name] = commit
+	}
+	return tags
+}
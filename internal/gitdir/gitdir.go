@@ -0,0 +1,85 @@
+package gitdir
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var ErrNotARepository = errors.New("not a git repository")
+
+type Repo struct {
+	WorkTree  string
+	GitDir    string
+	CommonDir string
+}
+
+func Find(path string) (Repo, error) {
+	dir, err := filepath.Abs(path)
+	if err != nil {
+		return Repo{}, err
+	}
+	for {
+		candidate := filepath.Join(dir, ".git")
+		info, err := os.Stat(candidate)
+		if err == nil {
+			gitDir := candidate
+			if !info.IsDir() {
+				gitDir, err = readGitFile(candidate)
+				if err != nil {
+					return Repo{}, err
+				}
+			}
+			return Repo{WorkTree: dir, GitDir: gitDir, CommonDir: commonDir(gitDir)}, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return Repo{}, ErrNotARepository
+		}
+		dir = parent
+	}
+}
+
+func readGitFile(path string) (string, error) {
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(body)), "gitdir:")
+	if !ok {
+		return "", errors.New("invalid .git file: " + path)
+	}
+	gitDir = strings.TrimSpace(gitDir)
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(filepath.Dir(path), gitDir)
+	}
+	return filepath.Clean(gitDir), nil
+}
+
+func commonDir(gitDir string) string {
+	body, err := os.ReadFile(filepath.Join(gitDir, "commondir"))
+	if err != nil {
+		return gitDir
+	}
+	dir := strings.TrimSpace(string(body))
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(gitDir, dir)
+	}
+	return filepath.Clean(dir)
+}
+
+func (r Repo) Head() string {
+	body, err := os.ReadFile(filepath.Join(r.GitDir, "HEAD"))
+	if err != nil {
+		return ""
+	}
+	head := strings.TrimSpace(string(body))
+	if ref, ok := strings.CutPrefix(head, "ref: "); ok {
+		return strings.TrimPrefix(ref, "refs/heads/")
+	}
+	if len(head) > 7 {
+		return head[:7]
+	}
+	return head
+}
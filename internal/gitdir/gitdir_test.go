@@ -0,0 +1,56 @@
+package gitdir
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindFollowsGitFileAndReadsHead(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	common := filepath.Join(root, "main", ".git")
+	gitDir := filepath.Join(common, "worktrees", "feature")
+	mustWrite(t, filepath.Join(gitDir, "HEAD"), "ref: refs/heads/feature/login\n")
+	mustWrite(t, filepath.Join(gitDir, "commondir"), "../..\n")
+	work := filepath.Join(root, "feature")
+	mustWrite(t, filepath.Join(work, ".git"), "gitdir: ../main/.git/worktrees/feature\n")
+	if err := os.MkdirAll(filepath.Join(work, "src", "pkg"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	repo, err := Find(filepath.Join(work, "src", "pkg"))
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if repo.WorkTree != work || repo.GitDir != gitDir || repo.CommonDir != common {
+		t.Fatalf("Find() = %+v", repo)
+	}
+	if got := repo.Head(); got != "feature/login" {
+		t.Fatalf("Head() = %q, want feature/login", got)
+	}
+
+	mustWrite(t, filepath.Join(gitDir, "HEAD"), "0123456789abcdef0123456789abcdef01234567\n")
+	if got := repo.Head(); got != "0123456" {
+		t.Fatalf("detached Head() = %q, want 0123456", got)
+	}
+}
+
+func TestFindOutsideRepository(t *testing.T) {
+	t.Parallel()
+
+	if _, err := Find(t.TempDir()); err != ErrNotARepository {
+		t.Fatalf("Find() error = %v, want ErrNotARepository", err)
+	}
+}
+
+func mustWrite(t *testing.T, path string, body string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
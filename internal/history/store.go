@@ -0,0 +1,229 @@
+package history
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+	"github.com/guionardo/git_sync_status/internal/gitdir"
+)
+
+const lockTimeout = 2 * time.Second
+
+var reposBucket = []byte("repos")
+
+type Entry struct {
+	Repo   string
+	Branch string
+	Status domain.Status
+	Ahead  int
+	Behind int
+	Flags  []string
+	At     time.Time
+}
+
+type Store struct {
+	path string
+	mu   sync.Mutex
+}
+
+func DefaultPath() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "git-sync-status", "history.db"), nil
+}
+
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+func (s *Store) Path() string {
+	return s.path
+}
+
+func FromResult(result domain.Result, at time.Time) Entry {
+	return Entry{
+		Repo:   RepoKey(result.RepoPath),
+		Branch: result.Branch,
+		Status: result.Status,
+		Ahead:  result.Ahead,
+		Behind: result.Behind,
+		Flags:  result.Flags,
+		At:     at,
+	}
+}
+
+func RepoKey(repoPath string) string {
+	if repo, err := gitdir.Find(repoPath); err == nil {
+		return repo.WorkTree
+	}
+	if abs, err := filepath.Abs(repoPath); err == nil {
+		return abs
+	}
+	return repoPath
+}
+
+func (s *Store) Record(result domain.Result, at time.Time) error {
+	return s.Append(FromResult(result, at))
+}
+
+func (s *Store) Append(entry Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	db, err := bolt.Open(s.path, 0o644, &bolt.Options{Timeout: lockTimeout})
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	value, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return db.Update(func(tx *bolt.Tx) error {
+		repos, err := tx.CreateBucketIfNotExists(reposBucket)
+		if err != nil {
+			return err
+		}
+		bucket, err := repos.CreateBucketIfNotExists([]byte(entry.Repo))
+		if err != nil {
+			return err
+		}
+		return bucket.Put(entryKey(entry), value)
+	})
+}
+
+func (s *Store) Entries(repo string, branch string, since time.Time) ([]Entry, error) {
+	var entries []Entry
+	err := s.view(func(repos *bolt.Bucket) error {
+		bucket := repos.Bucket([]byte(repo))
+		if bucket == nil {
+			return nil
+		}
+		c := bucket.Cursor()
+		for k, v := c.Seek(timeKey(since)); k != nil; k, v = c.Next() {
+			var entry Entry
+			if err := json.Unmarshal(v, &entry); err != nil {
+				return err
+			}
+			if branch == "" || entry.Branch == branch {
+				entries = append(entries, entry)
+			}
+		}
+		return nil
+	})
+	return entries, err
+}
+
+func (s *Store) Summaries(repo string, branch string, since time.Time) ([]Summary, error) {
+	entries, err := s.Entries(repo, branch, since)
+	if err != nil {
+		return nil, err
+	}
+	summaries := Summarize(entries)
+	for i := range summaries {
+		if err := s.lookBack(repo, since, &summaries[i]); err != nil {
+			return nil, err
+		}
+	}
+	return summaries, nil
+}
+
+func (s *Store) lookBack(repo string, before time.Time, summary *Summary) error {
+	extendStatus := summary.StatusSince.Equal(summary.FirstSeen)
+	findSynced := summary.LastSynced.IsZero()
+	if !extendStatus && !findSynced {
+		return nil
+	}
+	return s.view(func(repos *bolt.Bucket) error {
+		bucket := repos.Bucket([]byte(repo))
+		if bucket == nil {
+			return nil
+		}
+		c := bucket.Cursor()
+		k, v := c.Seek(timeKey(before))
+		if k == nil {
+			k, v = c.Last()
+		} else {
+			k, v = c.Prev()
+		}
+		for ; k != nil && (extendStatus || findSynced); k, v = c.Prev() {
+			var entry Entry
+			if err := json.Unmarshal(v, &entry); err != nil {
+				return err
+			}
+			if entry.Branch != summary.Branch || !entry.At.Before(before) {
+				continue
+			}
+			if extendStatus {
+				if entry.Status == summary.Status {
+					summary.StatusSince = entry.At
+				} else {
+					extendStatus = false
+				}
+			}
+			if findSynced && entry.Status == domain.StatusSynced {
+				summary.LastSynced = entry.At
+				findSynced = false
+			}
+		}
+		return nil
+	})
+}
+
+func (s *Store) Repos() ([]string, error) {
+	var repos []string
+	err := s.view(func(bucket *bolt.Bucket) error {
+		return bucket.ForEachBucket(func(k []byte) error {
+			repos = append(repos, string(k))
+			return nil
+		})
+	})
+	sort.Strings(repos)
+	return repos, err
+}
+
+func (s *Store) view(fn func(repos *bolt.Bucket) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := os.Stat(s.path); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	db, err := bolt.Open(s.path, 0o644, &bolt.Options{Timeout: lockTimeout, ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	return db.View(func(tx *bolt.Tx) error {
+		repos := tx.Bucket(reposBucket)
+		if repos == nil {
+			return nil
+		}
+		return fn(repos)
+	})
+}
+
+func entryKey(entry Entry) []byte {
+	return append(timeKey(entry.At), entry.Branch...)
+}
+
+func timeKey(t time.Time) []byte {
+	key := make([]byte, 8)
+	if !t.IsZero() && t.UnixNano() > 0 {
+		binary.BigEndian.PutUint64(key, uint64(t.UnixNano()))
+	}
+	return key
+}
@@ -0,0 +1,111 @@
+package history
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+)
+
+func TestStoreAppendAndEntries(t *testing.T) {
+	t.Parallel()
+
+	store := NewStore(filepath.Join(t.TempDir(), "nested", "history.db"))
+	if entries, err := store.Entries("/src/api", "", time.Time{}); err != nil || len(entries) != 0 {
+		t.Fatalf("Entries() on missing store = %v, %v", entries, err)
+	}
+
+	base := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	for i, e := range []Entry{
+		{Repo: "/src/api", Branch: "main", Status: domain.StatusSynced, At: base},
+		{Repo: "/src/api", Branch: "feature", Status: domain.StatusSyncPending, Ahead: 2, At: base.Add(time.Minute)},
+		{Repo: "/src/api", Branch: "main", Status: domain.StatusLate, Behind: 3, Flags: []string{"WORKTREE_DIRTY"}, At: base.Add(2 * time.Hour)},
+		{Repo: "/src/web", Branch: "main", Status: domain.StatusSynced, At: base},
+	} {
+		if err := store.Append(e); err != nil {
+			t.Fatalf("Append(%d): %v", i, err)
+		}
+	}
+
+	entries, err := store.Entries("/src/api", "main", time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].Status != domain.StatusSynced || entries[1].Behind != 3 || entries[1].Flags[0] != "WORKTREE_DIRTY" {
+		t.Fatalf("main entries = %+v", entries)
+	}
+	if !entries[1].At.Equal(base.Add(2 * time.Hour)) {
+		t.Fatalf("At = %s", entries[1].At)
+	}
+
+	recent, err := store.Entries("/src/api", "", base.Add(time.Hour))
+	if err != nil || len(recent) != 1 || recent[0].Status != domain.StatusLate {
+		t.Fatalf("entries since +1h = %+v, %v", recent, err)
+	}
+
+	repos, err := store.Repos()
+	if err != nil || len(repos) != 2 || repos[0] != "/src/api" || repos[1] != "/src/web" {
+		t.Fatalf("Repos() = %v, %v", repos, err)
+	}
+}
+
+func TestStoreRecordUsesRepoKey(t *testing.T) {
+	t.Parallel()
+
+	store := NewStore(filepath.Join(t.TempDir(), "history.db"))
+	dir := t.TempDir()
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	if err := store.Record(domain.Result{RepoPath: dir, Branch: "main", Status: domain.StatusDiverged, Ahead: 1, Behind: 1}, at); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := store.Entries(RepoKey(dir), "", time.Time{})
+	if err != nil || len(entries) != 1 || entries[0].Status != domain.StatusDiverged {
+		t.Fatalf("entries = %+v, %v", entries, err)
+	}
+}
+
+func TestStoreSummariesLookBeyondWindow(t *testing.T) {
+	t.Parallel()
+
+	store := NewStore(filepath.Join(t.TempDir(), "history.db"))
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	for i, e := range []Entry{
+		{Repo: "/src/api", Branch: "main", Status: domain.StatusSynced, At: base},
+		{Repo: "/src/api", Branch: "main", Status: domain.StatusDiverged, At: base.Add(10 * day)},
+		{Repo: "/src/api", Branch: "feature", Status: domain.StatusSynced, At: base.Add(20 * day)},
+		{Repo: "/src/api", Branch: "main", Status: domain.StatusDiverged, At: base.Add(40 * day)},
+		{Repo: "/src/api", Branch: "main", Status: domain.StatusDiverged, At: base.Add(70 * day)},
+		{Repo: "/src/api", Branch: "feature", Status: domain.StatusLate, At: base.Add(65 * day)},
+	} {
+		if err := store.Append(e); err != nil {
+			t.Fatalf("Append(%d): %v", i, err)
+		}
+	}
+
+	now := base.Add(70 * day)
+	summaries, err := store.Summaries("/src/api", "", now.Add(-30*day))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(summaries) != 2 {
+		t.Fatalf("summaries = %+v", summaries)
+	}
+	feature, main := summaries[0], summaries[1]
+	if got := main.StatusFor(now); got != 60*day {
+		t.Fatalf("main StatusFor() = %s, want 60 days", got)
+	}
+	if days, ok := main.DaysSinceSynced(now); !ok || days != 70 {
+		t.Fatalf("main DaysSinceSynced() = %d, %v; want 70", days, ok)
+	}
+	if main.Samples != 2 || len(main.BehindSeries) != 2 {
+		t.Fatalf("main series = %d samples, %v; want only the window", main.Samples, main.BehindSeries)
+	}
+	if got := feature.StatusFor(now); got != 5*day {
+		t.Fatalf("feature StatusFor() = %s, want 5 days", got)
+	}
+	if days, ok := feature.DaysSinceSynced(now); !ok || days != 50 {
+		t.Fatalf("feature DaysSinceSynced() = %d, %v; want 50", days, ok)
+	}
+}
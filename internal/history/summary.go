@@ -0,0 +1,67 @@
+package history
+
+import (
+	"sort"
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+)
+
+type Summary struct {
+	Repo         string
+	Branch       string
+	Status       domain.Status
+	Ahead        int
+	Behind       int
+	StatusSince  time.Time
+	LastSynced   time.Time
+	FirstSeen    time.Time
+	LastSeen     time.Time
+	Samples      int
+	AheadSeries  []int
+	BehindSeries []int
+}
+
+func Summarize(entries []Entry) []Summary {
+	byBranch := map[string]*Summary{}
+	var order []string
+	for _, entry := range entries {
+		s, ok := byBranch[entry.Branch]
+		if !ok {
+			s = &Summary{Repo: entry.Repo, Branch: entry.Branch, FirstSeen: entry.At}
+			byBranch[entry.Branch] = s
+			order = append(order, entry.Branch)
+		}
+		if s.Samples == 0 || entry.Status != s.Status {
+			s.StatusSince = entry.At
+		}
+		if entry.Status == domain.StatusSynced {
+			s.LastSynced = entry.At
+		}
+		s.Status = entry.Status
+		s.Ahead = entry.Ahead
+		s.Behind = entry.Behind
+		s.LastSeen = entry.At
+		s.Samples++
+		s.AheadSeries = append(s.AheadSeries, entry.Ahead)
+		s.BehindSeries = append(s.BehindSeries, entry.Behind)
+	}
+
+	sort.Strings(order)
+	summaries := make([]Summary, 0, len(order))
+	for _, branch := range order {
+		summaries = append(summaries, *byBranch[branch])
+	}
+	return summaries
+}
+
+func (s Summary) StatusFor(now time.Time) time.Duration {
+	return now.Sub(s.StatusSince)
+}
+
+func (s Summary) DaysSinceSynced(now time.Time) (int, bool) {
+	if s.LastSynced.IsZero() {
+		return 0, false
+	}
+	return int(now.Sub(s.LastSynced).Hours() / 24), true
+}
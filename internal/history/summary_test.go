@@ -0,0 +1,43 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+)
+
+func TestSummarize(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	summaries := Summarize([]Entry{
+		{Branch: "main", Status: domain.StatusSynced, At: base},
+		{Branch: "main", Status: domain.StatusDiverged, Ahead: 1, Behind: 2, At: base.Add(day)},
+		{Branch: "main", Status: domain.StatusDiverged, Ahead: 1, Behind: 4, At: base.Add(2 * day)},
+		{Branch: "feature", Status: domain.StatusSyncPending, Ahead: 3, At: base.Add(day)},
+	})
+	if len(summaries) != 2 || summaries[0].Branch != "feature" || summaries[1].Branch != "main" {
+		t.Fatalf("summaries = %+v", summaries)
+	}
+
+	main := summaries[1]
+	now := base.Add(3 * day)
+	if main.Status != domain.StatusDiverged || main.Samples != 3 || main.Behind != 4 {
+		t.Fatalf("main = %+v", main)
+	}
+	if got := main.StatusFor(now); got != 2*day {
+		t.Fatalf("StatusFor() = %s, want 48h", got)
+	}
+	if days, ok := main.DaysSinceSynced(now); !ok || days != 3 {
+		t.Fatalf("DaysSinceSynced() = %d, %v", days, ok)
+	}
+	if len(main.BehindSeries) != 3 || main.BehindSeries[2] != 4 {
+		t.Fatalf("BehindSeries = %v", main.BehindSeries)
+	}
+
+	if _, ok := summaries[0].DaysSinceSynced(now); ok {
+		t.Fatal("feature was never synced")
+	}
+}
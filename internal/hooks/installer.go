@@ -0,0 +1,137 @@
+package hooks
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	marker         = "# installed by git-sync-status install-hooks"
+	originalSuffix = ".sync-status-orig"
+)
+
+var Names = []string{"pre-push", "post-checkout"}
+
+const BinaryName = "git-sync-status"
+
+func LookupBinary() (string, error) {
+	path, err := exec.LookPath(BinaryName)
+	if err != nil {
+		return "", fmt.Errorf("%s not found on PATH: install it or add its directory to PATH before installing hooks", BinaryName)
+	}
+	return filepath.Abs(path)
+}
+
+type Change struct {
+	Hook   string
+	Action string
+}
+
+func Install(hooksDir string, binary string) ([]Change, error) {
+	if err := os.MkdirAll(hooksDir, 0o755); err != nil {
+		return nil, err
+	}
+
+	var changes []Change
+	for _, name := range Names {
+		target := filepath.Join(hooksDir, name)
+		action := "installed"
+
+		installed, err := isInstalled(target)
+		if err != nil {
+			return changes, err
+		}
+		switch {
+		case installed:
+			action = "updated"
+		case fileExists(target):
+			if fileExists(target + originalSuffix) {
+				return changes, fmt.Errorf("cannot chain %s: %s already exists", name, target+originalSuffix)
+			}
+			if err := os.Rename(target, target+originalSuffix); err != nil {
+				return changes, err
+			}
+			action = "installed (chained with existing hook)"
+		}
+
+		if err := os.WriteFile(target, []byte(script(name, binary)), 0o755); err != nil {
+			return changes, err
+		}
+		changes = append(changes, Change{Hook: name, Action: action})
+	}
+	return changes, nil
+}
+
+func Uninstall(hooksDir string) ([]Change, error) {
+	var changes []Change
+	for _, name := range Names {
+		target := filepath.Join(hooksDir, name)
+		installed, err := isInstalled(target)
+		if err != nil {
+			return changes, err
+		}
+		if !installed {
+			if fileExists(target) {
+				changes = append(changes, Change{Hook: name, Action: "left untouched (not installed by git-sync-status)"})
+			}
+			continue
+		}
+
+		if err := os.Remove(target); err != nil {
+			return changes, err
+		}
+		action := "removed"
+		if fileExists(target + originalSuffix) {
+			if err := os.Rename(target+originalSuffix, target); err != nil {
+				return changes, err
+			}
+			action = "removed (original hook restored)"
+		}
+		changes = append(changes, Change{Hook: name, Action: action})
+	}
+	return changes, nil
+}
+
+func isInstalled(path string) (bool, error) {
+	body, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return strings.Contains(string(body), marker), nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
+
+func script(name string, binary string) string {
+	var b strings.Builder
+	b.WriteString("#!/bin/sh\n")
+	b.WriteString(marker + "\n")
+	fmt.Fprintf(&b, "orig=\"$(dirname \"$0\")/%s%s\"\n", name, originalSuffix)
+	if name == "pre-push" {
+		b.WriteString("input=$(cat)\n")
+		b.WriteString("if [ -x \"$orig\" ]; then\n")
+		b.WriteString("\tprintf '%s\\n' \"$input\" | \"$orig\" \"$@\" || exit $?\n")
+		b.WriteString("fi\n")
+		fmt.Fprintf(&b, "printf '%%s\\n' \"$input\" | exec %s hook %s \"$@\"\n", shellQuote(binary), name)
+		return b.String()
+	}
+	b.WriteString("if [ -x \"$orig\" ]; then\n")
+	b.WriteString("\t\"$orig\" \"$@\" || exit $?\n")
+	b.WriteString("fi\n")
+	fmt.Fprintf(&b, "exec %s hook %s \"$@\"\n", shellQuote(binary), name)
+	return b.String()
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
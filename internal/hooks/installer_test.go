@@ -0,0 +1,157 @@
+package hooks
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInstallChainsExistingHookAndUninstallRestoresIt(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	original := "#!/bin/sh\necho original \"$@\"\n"
+	writeHook(t, filepath.Join(dir, "pre-push"), original)
+
+	changes, err := Install(dir, "/usr/local/bin/git-sync-status")
+	if err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+	if len(changes) != 2 || !strings.Contains(changes[0].Action, "chained") || changes[1].Action != "installed" {
+		t.Fatalf("unexpected changes: %+v", changes)
+	}
+	if got := readHook(t, filepath.Join(dir, "pre-push"+originalSuffix)); got != original {
+		t.Fatalf("original hook = %q, want %q", got, original)
+	}
+	body := readHook(t, filepath.Join(dir, "pre-push"))
+	if !strings.Contains(body, marker) || !strings.Contains(body, "exec '/usr/local/bin/git-sync-status' hook pre-push") {
+		t.Fatalf("unexpected hook script:\n%s", body)
+	}
+
+	if _, err := Install(dir, "/usr/local/bin/git-sync-status"); err != nil {
+		t.Fatalf("second Install() error = %v", err)
+	}
+	if got := readHook(t, filepath.Join(dir, "pre-push"+originalSuffix)); got != original {
+		t.Fatalf("reinstall clobbered original hook: %q", got)
+	}
+
+	if _, err := Uninstall(dir); err != nil {
+		t.Fatalf("Uninstall() error = %v", err)
+	}
+	if got := readHook(t, filepath.Join(dir, "pre-push")); got != original {
+		t.Fatalf("restored hook = %q, want %q", got, original)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "post-checkout")); !os.IsNotExist(err) {
+		t.Fatalf("post-checkout should be removed, stat error = %v", err)
+	}
+}
+
+func TestUninstallLeavesForeignHooksAlone(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	foreign := "#!/bin/sh\nexit 0\n"
+	writeHook(t, filepath.Join(dir, "post-checkout"), foreign)
+
+	changes, err := Uninstall(dir)
+	if err != nil {
+		t.Fatalf("Uninstall() error = %v", err)
+	}
+	if len(changes) != 1 || !strings.Contains(changes[0].Action, "untouched") {
+		t.Fatalf("unexpected changes: %+v", changes)
+	}
+	if got := readHook(t, filepath.Join(dir, "post-checkout")); got != foreign {
+		t.Fatalf("foreign hook modified: %q", got)
+	}
+}
+
+func TestInstalledPrePushForwardsStdinToOriginalAndStopsOnFailure(t *testing.T) {
+	t.Parallel()
+
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+
+	dir := t.TempDir()
+	seen := filepath.Join(dir, "seen")
+	writeHook(t, filepath.Join(dir, "pre-push"), "#!/bin/sh\ncat > '"+seen+"'\nexit 3\n")
+	binary := filepath.Join(dir, "fake-sync-status")
+	writeHook(t, binary, "#!/bin/sh\necho reached > '"+filepath.Join(dir, "reached")+"'\n")
+
+	if _, err := Install(dir, binary); err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+
+	cmd := exec.Command(filepath.Join(dir, "pre-push"), "origin", "git@example.com:repo.git")
+	cmd.Stdin = strings.NewReader("refs/heads/main abc refs/heads/main def\n")
+	err := cmd.Run()
+	exitErr, ok := err.(*exec.ExitError)
+	if !ok || exitErr.ExitCode() != 3 {
+		t.Fatalf("hook error = %v, want exit code 3", err)
+	}
+	if got := readHook(t, seen); got != "refs/heads/main abc refs/heads/main def\n" {
+		t.Fatalf("original hook stdin = %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "reached")); !os.IsNotExist(err) {
+		t.Fatalf("analyzer should not run after original hook failure")
+	}
+}
+
+func TestInstalledPrePushForwardsStdinToBinary(t *testing.T) {
+	t.Parallel()
+
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+
+	dir := t.TempDir()
+	seen := filepath.Join(dir, "seen")
+	binary := filepath.Join(dir, "fake-sync-status")
+	writeHook(t, binary, "#!/bin/sh\necho \"$@\" > '"+seen+"'\ncat >> '"+seen+"'\n")
+
+	if _, err := Install(dir, binary); err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+
+	cmd := exec.Command(filepath.Join(dir, "pre-push"), "origin", "git@example.com:repo.git")
+	cmd.Stdin = strings.NewReader("refs/heads/feature abc refs/heads/feature def\n")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("hook error = %v: %s", err, out)
+	}
+	want := "hook pre-push origin git@example.com:repo.git\nrefs/heads/feature abc refs/heads/feature def\n"
+	if got := readHook(t, seen); got != want {
+		t.Fatalf("binary saw %q, want %q", got, want)
+	}
+}
+
+func TestLookupBinaryUsesPath(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("PATH", dir)
+	if _, err := LookupBinary(); err == nil || !strings.Contains(err.Error(), "not found on PATH") {
+		t.Fatalf("LookupBinary() without binary error = %v", err)
+	}
+
+	writeHook(t, filepath.Join(dir, BinaryName), "#!/bin/sh\n")
+	got, err := LookupBinary()
+	if err != nil || got != filepath.Join(dir, BinaryName) {
+		t.Fatalf("LookupBinary() = %q, %v", got, err)
+	}
+}
+
+func writeHook(t *testing.T, path string, body string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(body), 0o755); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func readHook(t *testing.T, path string) string {
+	t.Helper()
+	body, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	return string(body)
+}
@@ -0,0 +1,68 @@
+package hooks
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+)
+
+const (
+	ConfigPrePush      = "sync-status.prePush"
+	ConfigPostCheckout = "sync-status.postCheckout"
+
+	ModeBlock  = "block"
+	ModeWarn   = "warn"
+	ModeStatus = "status"
+	ModeOff    = "off"
+)
+
+func PrePushMode(configured string) string {
+	switch strings.ToLower(strings.TrimSpace(configured)) {
+	case ModeWarn:
+		return ModeWarn
+	case ModeOff:
+		return ModeOff
+	default:
+		return ModeBlock
+	}
+}
+
+func PostCheckoutMode(configured string) string {
+	if strings.ToLower(strings.TrimSpace(configured)) == ModeOff {
+		return ModeOff
+	}
+	return ModeStatus
+}
+
+func PrePushVerdict(result domain.Result, mode string) (block bool, message string) {
+	message = Summary(result)
+	if mode == ModeOff {
+		return false, ""
+	}
+	if result.Status != domain.StatusDiverged && result.Status != domain.StatusLate {
+		return false, message
+	}
+	if mode == ModeWarn {
+		return false, message + " (warning: pull before pushing)"
+	}
+	return true, fmt.Sprintf("%s\npush refused: branch is %s; run git pull --rebase first or set git config %s warn", message, result.Status, ConfigPrePush)
+}
+
+func Summary(result domain.Result) string {
+	parts := []string{"git-sync-status:", fallback(result.Branch, "(unknown)"), string(result.Status)}
+	if result.Ahead > 0 || result.Behind > 0 {
+		parts = append(parts, fmt.Sprintf("ahead=%d behind=%d", result.Ahead, result.Behind))
+	}
+	if len(result.Flags) > 0 {
+		parts = append(parts, "["+strings.Join(result.Flags, ",")+"]")
+	}
+	return strings.Join(parts, " ")
+}
+
+func fallback(value string, alt string) string {
+	if strings.TrimSpace(value) == "" {
+		return alt
+	}
+	return value
+}
@@ -0,0 +1,50 @@
+package hooks
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+)
+
+func TestPrePushVerdict(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		status    domain.Status
+		mode      string
+		wantBlock bool
+	}{
+		{name: "synced pushes", status: domain.StatusSynced, mode: ModeBlock},
+		{name: "pending pushes", status: domain.StatusSyncPending, mode: ModeBlock},
+		{name: "late blocks", status: domain.StatusLate, mode: ModeBlock, wantBlock: true},
+		{name: "diverged blocks", status: domain.StatusDiverged, mode: ModeBlock, wantBlock: true},
+		{name: "diverged warns", status: domain.StatusDiverged, mode: ModeWarn},
+		{name: "off never blocks", status: domain.StatusDiverged, mode: ModeOff},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			block, message := PrePushVerdict(domain.Result{Branch: "main", Status: tt.status}, tt.mode)
+			if block != tt.wantBlock {
+				t.Fatalf("block = %v, want %v (message %q)", block, tt.wantBlock, message)
+			}
+			if tt.wantBlock && !strings.Contains(message, "push refused") {
+				t.Fatalf("message = %q, want refusal", message)
+			}
+		})
+	}
+}
+
+func TestPrePushModeDefaultsToBlock(t *testing.T) {
+	t.Parallel()
+
+	if got := PrePushMode(""); got != ModeBlock {
+		t.Fatalf("PrePushMode(\"\") = %q, want %q", got, ModeBlock)
+	}
+	if got := PrePushMode(" Warn "); got != ModeWarn {
+		t.Fatalf("PrePushMode(\" Warn \") = %q, want %q", got, ModeWarn)
+	}
+}
@@ -0,0 +1,32 @@
+package hooks
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/guionardo/git_sync_status/internal/service"
+)
+
+func ParsePushUpdates(r io.Reader) ([]service.PushUpdate, error) {
+	var updates []service.PushUpdate
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("invalid pre-push line %q", line)
+		}
+		updates = append(updates, service.PushUpdate{
+			LocalRef:  fields[0],
+			LocalSHA:  fields[1],
+			RemoteRef: fields[2],
+			RemoteSHA: fields[3],
+		})
+	}
+	return updates, scanner.Err()
+}
@@ -0,0 +1,31 @@
+package hooks
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParsePushUpdates(t *testing.T) {
+	t.Parallel()
+
+	zero := strings.Repeat("0", 40)
+	input := "refs/heads/feature " + strings.Repeat("a", 40) + " refs/heads/feature " + zero + "\n\n" +
+		"(delete) " + zero + " refs/heads/old " + strings.Repeat("b", 40) + "\n"
+	updates, err := ParsePushUpdates(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParsePushUpdates() error = %v", err)
+	}
+	if len(updates) != 2 {
+		t.Fatalf("got %d updates, want 2", len(updates))
+	}
+	if updates[0].LocalRef != "refs/heads/feature" || !updates[0].CreatesRef() || updates[0].Deletes() {
+		t.Fatalf("unexpected first update: %+v", updates[0])
+	}
+	if !updates[1].Deletes() || updates[1].RemoteRef != "refs/heads/old" {
+		t.Fatalf("unexpected second update: %+v", updates[1])
+	}
+
+	if _, err := ParsePushUpdates(strings.NewReader("refs/heads/main abc\n")); err == nil {
+		t.Fatal("expected error for malformed line")
+	}
+}
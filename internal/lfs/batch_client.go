@@ -0,0 +1,121 @@
+package lfs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	mediaType      = "application/vnd.git-lfs+json"
+	DefaultTimeout = 15 * time.Second
+	MaxBatchSize   = 100
+)
+
+var ErrAuthRequired = errors.New("lfs server requires authentication")
+
+type Endpoint struct {
+	URL      string
+	Header   map[string]string
+	Username string
+	Password string
+}
+
+type Object struct {
+	OID  string `json:"oid"`
+	Size int64  `json:"size"`
+}
+
+type BatchClient struct {
+	http *http.Client
+}
+
+func NewBatchClient(httpClient *http.Client) *BatchClient {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: DefaultTimeout}
+	}
+	return &BatchClient{http: httpClient}
+}
+
+type batchRequest struct {
+	Operation string   `json:"operation"`
+	Transfers []string `json:"transfers"`
+	Objects   []Object `json:"objects"`
+}
+
+type batchResponse struct {
+	Objects []struct {
+		Object
+		Actions map[string]json.RawMessage `json:"actions"`
+		Error   *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	} `json:"objects"`
+}
+
+func (c *BatchClient) MissingObjects(ctx context.Context, endpoint Endpoint, objects []Object) ([]Object, error) {
+	var missing []Object
+	for start := 0; start < len(objects); start += MaxBatchSize {
+		batch := objects[start:min(start+MaxBatchSize, len(objects))]
+		found, err := c.missingInBatch(ctx, endpoint, batch)
+		if err != nil {
+			return nil, err
+		}
+		missing = append(missing, found...)
+	}
+	return missing, nil
+}
+
+func (c *BatchClient) missingInBatch(ctx context.Context, endpoint Endpoint, objects []Object) ([]Object, error) {
+	body, err := json.Marshal(batchRequest{Operation: "upload", Transfers: []string{"basic"}, Objects: objects})
+	if err != nil {
+		return nil, err
+	}
+	url := strings.TrimSuffix(endpoint.URL, "/") + "/objects/batch"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", mediaType)
+	req.Header.Set("Content-Type", mediaType)
+	for name, value := range endpoint.Header {
+		req.Header.Set(name, value)
+	}
+	if endpoint.Password != "" {
+		req.SetBasicAuth(endpoint.Username, endpoint.Password)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return nil, fmt.Errorf("%w: %s returned %s", ErrAuthRequired, url, resp.Status)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("lfs batch request to %s failed: %s", url, resp.Status)
+	}
+
+	var decoded batchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("invalid lfs batch response: %w", err)
+	}
+
+	var missing []Object
+	for _, obj := range decoded.Objects {
+		if obj.Error != nil {
+			return nil, fmt.Errorf("lfs object %s: %s", obj.OID, obj.Error.Message)
+		}
+		if _, ok := obj.Actions["upload"]; ok {
+			missing = append(missing, obj.Object)
+		}
+	}
+	return missing, nil
+}
@@ -0,0 +1,133 @@
+package lfs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBatchClientMissingObjects(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/info/lfs/objects/batch" || r.Header.Get("Content-Type") != mediaType {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		var req batchRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Operation != "upload" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", mediaType)
+		_, _ = w.Write([]byte(`{"objects":[
+			{"oid":"aaa","size":1},
+			{"oid":"bbb","size":2,"actions":{"upload":{"href":"http://example/upload"}}}
+		]}`))
+	}))
+	defer srv.Close()
+
+	missing, err := NewBatchClient(srv.Client()).MissingObjects(context.Background(), Endpoint{URL: srv.URL + "/info/lfs"}, []Object{
+		{OID: "aaa", Size: 1},
+		{OID: "bbb", Size: 2},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(missing) != 1 || missing[0].OID != "bbb" || missing[0].Size != 2 {
+		t.Fatalf("got %+v, want only bbb", missing)
+	}
+}
+
+func TestBatchClientServerError(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	if _, err := NewBatchClient(srv.Client()).MissingObjects(context.Background(), Endpoint{URL: srv.URL}, []Object{{OID: "a", Size: 1}}); err == nil {
+		t.Fatalf("expected error")
+	}
+}
+
+func TestBatchClientAuthentication(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "alice" || pass != "token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", mediaType)
+		_, _ = w.Write([]byte(`{"objects":[{"oid":"a","size":1}]}`))
+	}))
+	defer srv.Close()
+
+	objects := []Object{{OID: "a", Size: 1}}
+	client := NewBatchClient(srv.Client())
+	if _, err := client.MissingObjects(context.Background(), Endpoint{URL: srv.URL}, objects); !errors.Is(err, ErrAuthRequired) {
+		t.Fatalf("anonymous request error = %v, want ErrAuthRequired", err)
+	}
+	missing, err := client.MissingObjects(context.Background(), Endpoint{URL: srv.URL, Username: "alice", Password: "token"}, objects)
+	if err != nil || len(missing) != 0 {
+		t.Fatalf("authenticated request = %v, %v", missing, err)
+	}
+}
+
+func TestNewBatchClientHasTimeout(t *testing.T) {
+	t.Parallel()
+
+	if got := NewBatchClient(nil).http.Timeout; got != DefaultTimeout {
+		t.Fatalf("timeout = %s, want %s", got, DefaultTimeout)
+	}
+}
+
+func TestBatchClientSplitsLargeRequests(t *testing.T) {
+	t.Parallel()
+
+	var sizes []int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "RemoteAuth token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		var req batchRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Objects) > MaxBatchSize {
+			http.Error(w, "too many objects", http.StatusUnprocessableEntity)
+			return
+		}
+		sizes = append(sizes, len(req.Objects))
+		resp := map[string][]map[string]any{"objects": {}}
+		for _, obj := range req.Objects {
+			resp["objects"] = append(resp["objects"], map[string]any{
+				"oid": obj.OID, "size": obj.Size,
+				"actions": map[string]any{"upload": map[string]string{"href": "http://example/upload"}},
+			})
+		}
+		w.Header().Set("Content-Type", mediaType)
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	objects := make([]Object, 250)
+	for i := range objects {
+		objects[i] = Object{OID: fmt.Sprintf("%064d", i), Size: int64(i)}
+	}
+	endpoint := Endpoint{URL: srv.URL, Header: map[string]string{"Authorization": "RemoteAuth token"}}
+	missing, err := NewBatchClient(srv.Client()).MissingObjects(context.Background(), endpoint, objects)
+	if err != nil {
+		t.Fatalf("MissingObjects: %v", err)
+	}
+	if len(missing) != len(objects) || missing[249].OID != objects[249].OID {
+		t.Fatalf("got %d missing objects, want %d", len(missing), len(objects))
+	}
+	if len(sizes) != 3 || sizes[0] != 100 || sizes[1] != 100 || sizes[2] != 50 {
+		t.Fatalf("batch sizes = %v, want [100 100 50]", sizes)
+	}
+}
@@ -0,0 +1,208 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+	"github.com/guionardo/git_sync_status/internal/gitdir"
+)
+
+var statuses = []domain.Status{
+	domain.StatusNotAGitRepo,
+	domain.StatusNoRemote,
+	domain.StatusNoUpstream,
+	domain.StatusDetached,
+	domain.StatusSynced,
+	domain.StatusSyncPending,
+	domain.StatusLate,
+	domain.StatusDiverged,
+}
+
+type Analyzer interface {
+	Analyze(ctx context.Context, repoPath string) domain.Result
+}
+
+type sample struct {
+	result     domain.Result
+	duration   time.Duration
+	analyzedAt time.Time
+	fetchedAt  time.Time
+	runs       int
+}
+
+type Exporter struct {
+	analyzer Analyzer
+	repos    []string
+	interval time.Duration
+	now      func() time.Time
+
+	mu      sync.RWMutex
+	samples map[string]*sample
+}
+
+func NewExporter(analyzer Analyzer, repos []string, interval time.Duration) *Exporter {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &Exporter{
+		analyzer: analyzer,
+		repos:    repos,
+		interval: interval,
+		now:      time.Now,
+		samples:  map[string]*sample{},
+	}
+}
+
+func (e *Exporter) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		e.Collect(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *Exporter) Collect(ctx context.Context) {
+	for _, repo := range e.repos {
+		if ctx.Err() != nil {
+			return
+		}
+		started := e.now()
+		result := e.analyzer.Analyze(ctx, repo)
+		duration := e.now().Sub(started)
+
+		e.mu.Lock()
+		s, ok := e.samples[repo]
+		if !ok {
+			s = &sample{}
+			e.samples[repo] = s
+		}
+		s.result = result
+		s.duration = duration
+		s.analyzedAt = e.now()
+		s.fetchedAt = lastFetch(repo)
+		s.runs++
+		e.mu.Unlock()
+	}
+}
+
+func lastFetch(repoPath string) time.Time {
+	repo, err := gitdir.Find(repoPath)
+	if err != nil {
+		return time.Time{}
+	}
+	info, err := os.Stat(filepath.Join(repo.CommonDir, "FETCH_HEAD"))
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = e.Write(w)
+}
+
+func (e *Exporter) Write(w io.Writer) error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	repos := make([]string, 0, len(e.samples))
+	for repo := range e.samples {
+		repos = append(repos, repo)
+	}
+	sort.Strings(repos)
+	now := e.now()
+
+	var b strings.Builder
+	family(&b, "git_sync_ahead", "gauge", "Commits on the local branch that are not on its upstream.")
+	for _, repo := range repos {
+		s := e.samples[repo]
+		fmt.Fprintf(&b, "git_sync_ahead{%s} %d\n", repoLabels(repo, s.result), s.result.Ahead)
+	}
+	family(&b, "git_sync_behind", "gauge", "Commits on the upstream that are not on the local branch.")
+	for _, repo := range repos {
+		s := e.samples[repo]
+		fmt.Fprintf(&b, "git_sync_behind{%s} %d\n", repoLabels(repo, s.result), s.result.Behind)
+	}
+	family(&b, "git_sync_status", "gauge", "Sync status of the repository; 1 for the current status, 0 otherwise.")
+	for _, repo := range repos {
+		s := e.samples[repo]
+		for _, status := range statuses {
+			fmt.Fprintf(&b, "git_sync_status{%s,status=%s} %d\n", repoLabels(repo, s.result), quote(string(status)), boolValue(s.result.Status == status))
+		}
+	}
+	family(&b, "git_sync_dirty", "gauge", "1 when the working tree has staged, unstaged or untracked changes.")
+	for _, repo := range repos {
+		s := e.samples[repo]
+		fmt.Fprintf(&b, "git_sync_dirty{%s} %d\n", repoLabels(repo, s.result), boolValue(s.result.HasFlag("WORKTREE_DIRTY")))
+	}
+	family(&b, "git_sync_flag", "gauge", "1 for every flag raised by the last analysis.")
+	for _, repo := range repos {
+		s := e.samples[repo]
+		for _, flag := range s.result.Flags {
+			fmt.Fprintf(&b, "git_sync_flag{%s,flag=%s} 1\n", repoLabels(repo, s.result), quote(flag))
+		}
+	}
+	family(&b, "git_sync_last_fetch_age_seconds", "gauge", "Seconds since FETCH_HEAD was last written.")
+	for _, repo := range repos {
+		s := e.samples[repo]
+		if s.fetchedAt.IsZero() {
+			continue
+		}
+		fmt.Fprintf(&b, "git_sync_last_fetch_age_seconds{%s} %g\n", repoLabels(repo, s.result), now.Sub(s.fetchedAt).Seconds())
+	}
+	family(&b, "git_sync_analysis_duration_seconds", "gauge", "Duration of the last analysis.")
+	for _, repo := range repos {
+		s := e.samples[repo]
+		fmt.Fprintf(&b, "git_sync_analysis_duration_seconds{%s} %g\n", repoLabels(repo, s.result), s.duration.Seconds())
+	}
+	family(&b, "git_sync_last_analysis_timestamp_seconds", "gauge", "Unix time of the last analysis.")
+	for _, repo := range repos {
+		s := e.samples[repo]
+		fmt.Fprintf(&b, "git_sync_last_analysis_timestamp_seconds{%s} %d\n", repoLabels(repo, s.result), s.analyzedAt.Unix())
+	}
+	family(&b, "git_sync_analyses_total", "counter", "Analyses run since the exporter started.")
+	for _, repo := range repos {
+		s := e.samples[repo]
+		fmt.Fprintf(&b, "git_sync_analyses_total{%s} %d\n", repoLabels(repo, s.result), s.runs)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func family(b *strings.Builder, name string, kind string, help string) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func repoLabels(repo string, result domain.Result) string {
+	return fmt.Sprintf("repo=%s,branch=%s", quote(repo), quote(result.Branch))
+}
+
+func quote(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, "\n", `\n`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	return `"` + value + `"`
+}
+
+func boolValue(v bool) int {
+	if v {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+)
+
+type fakeAnalyzer map[string]domain.Result
+
+func (f fakeAnalyzer) Analyze(_ context.Context, repoPath string) domain.Result {
+	return f[repoPath]
+}
+
+func TestExporterServesMetrics(t *testing.T) {
+	t.Parallel()
+
+	repo := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	fetchHead := filepath.Join(repo, ".git", "FETCH_HEAD")
+	if err := os.WriteFile(fetchHead, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	if err := os.Chtimes(fetchHead, now.Add(-90*time.Second), now.Add(-90*time.Second)); err != nil {
+		t.Fatal(err)
+	}
+
+	analyzer := fakeAnalyzer{
+		repo:       {RepoPath: repo, Branch: "main", Status: domain.StatusDiverged, Ahead: 2, Behind: 5, Flags: []string{"WORKTREE_DIRTY"}},
+		"/missing": {RepoPath: "/missing", Status: domain.StatusNotAGitRepo},
+	}
+	exporter := NewExporter(analyzer, []string{repo, "/missing"}, time.Minute)
+	exporter.now = func() time.Time { return now }
+	exporter.Collect(context.Background())
+	exporter.Collect(context.Background())
+
+	rec := httptest.NewRecorder()
+	exporter.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+
+	labels := `repo="` + repo + `",branch="main"`
+	body := rec.Body.String()
+	for _, want := range []string{
+		"# TYPE git_sync_ahead gauge",
+		"git_sync_ahead{" + labels + "} 2",
+		"git_sync_behind{" + labels + "} 5",
+		"git_sync_status{" + labels + `,status="DIVERGED"} 1`,
+		"git_sync_status{" + labels + `,status="SYNCED"} 0`,
+		`git_sync_status{repo="/missing",branch="",status="NOT_A_GIT_REPO"} 1`,
+		"git_sync_dirty{" + labels + "} 1",
+		"git_sync_flag{" + labels + `,flag="WORKTREE_DIRTY"} 1`,
+		"git_sync_last_fetch_age_seconds{" + labels + "} 90",
+		"git_sync_analysis_duration_seconds{" + labels + "} 0",
+		"git_sync_last_analysis_timestamp_seconds{" + labels + "} 1714564800",
+		"git_sync_analyses_total{" + labels + "} 2",
+	} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("metrics missing %q:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, `git_sync_last_fetch_age_seconds{repo="/missing"`) {
+		t.Fatal("fetch age should be omitted when FETCH_HEAD is missing")
+	}
+}
+
+func TestQuoteEscapesLabelValues(t *testing.T) {
+	t.Parallel()
+
+	if got := quote("a\"b\\c\nd"); got != `"a\"b\\c\nd"` {
+		t.Fatalf("quote() = %s", got)
+	}
+}
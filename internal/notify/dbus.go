@@ -0,0 +1,72 @@
+package notify
+
+import (
+	"context"
+	"sync"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	dbusDestination = "org.freedesktop.Notifications"
+	dbusPath        = "/org/freedesktop/Notifications"
+	dbusMethod      = "org.freedesktop.Notifications.Notify"
+
+	urgencyNormal   byte = 1
+	urgencyCritical byte = 2
+)
+
+type DBusNotifier struct {
+	AppName string
+	Icon    string
+	Timeout int32
+
+	mu   sync.Mutex
+	conn *dbus.Conn
+}
+
+func NewDBusNotifier() *DBusNotifier {
+	return &DBusNotifier{AppName: "git-sync-status", Icon: "vcs-normal", Timeout: -1}
+}
+
+func (n *DBusNotifier) Notify(ctx context.Context, event Event) error {
+	conn, err := n.connect()
+	if err != nil {
+		return err
+	}
+
+	urgency := urgencyNormal
+	if event.Urgent() {
+		urgency = urgencyCritical
+	}
+	hints := map[string]dbus.Variant{"urgency": dbus.MakeVariant(urgency)}
+
+	call := conn.Object(dbusDestination, dbusPath).CallWithContext(ctx, dbusMethod, 0,
+		n.AppName, uint32(0), n.Icon, event.Title(), event.Body(), []string{}, hints, n.Timeout)
+	return call.Err
+}
+
+func (n *DBusNotifier) connect() (*dbus.Conn, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.conn != nil && n.conn.Connected() {
+		return n.conn, nil
+	}
+	conn, err := dbus.ConnectSessionBus()
+	if err != nil {
+		return nil, err
+	}
+	n.conn = conn
+	return conn, nil
+}
+
+func (n *DBusNotifier) Close() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.conn == nil {
+		return nil
+	}
+	err := n.conn.Close()
+	n.conn = nil
+	return err
+}
@@ -0,0 +1,71 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+)
+
+const (
+	ReasonStatus         = "status"
+	ReasonFetchFailing   = "fetch_failing"
+	ReasonFetchRecovered = "fetch_recovered"
+)
+
+type Event struct {
+	Repo   string
+	Branch string
+	Reason string
+	From   domain.Status
+	To     domain.Status
+	Result domain.Result
+	At     time.Time
+}
+
+func (e Event) Title() string {
+	switch e.Reason {
+	case ReasonFetchFailing:
+		return fmt.Sprintf("%s: fetch failing", e.Repo)
+	case ReasonFetchRecovered:
+		return fmt.Sprintf("%s: fetch recovered", e.Repo)
+	default:
+		return fmt.Sprintf("%s: %s → %s", e.Repo, e.From, e.To)
+	}
+}
+
+func (e Event) Body() string {
+	body := fmt.Sprintf("%s is %s (ahead %d, behind %d)", fallback(e.Branch, "HEAD"), e.To, e.Result.Ahead, e.Result.Behind)
+	if len(e.Result.Actions) > 0 {
+		body += "\n" + e.Result.Actions[0]
+	}
+	return body
+}
+
+func (e Event) Urgent() bool {
+	return e.Reason == ReasonFetchFailing || e.To.Severity() == domain.SeverityError
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, event Event) error
+}
+
+type Multi []Notifier
+
+func (m Multi) Notify(ctx context.Context, event Event) error {
+	var firstErr error
+	for _, n := range m {
+		if err := n.Notify(ctx, event); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func fallback(value string, alt string) string {
+	if value == "" {
+		return alt
+	}
+	return value
+}
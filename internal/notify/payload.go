@@ -0,0 +1,152 @@
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+)
+
+const (
+	ProblemDirty    = "dirty"
+	ProblemDiverged = "diverged"
+	ProblemUnpushed = "unpushed"
+)
+
+type Problem struct {
+	Repo     string
+	Branch   string
+	Status   domain.Status
+	Ahead    int
+	Behind   int
+	Flags    []string
+	Problems []string
+}
+
+type Payload struct {
+	Title        string
+	Host         string
+	GeneratedAt  time.Time
+	Repositories int
+	Problems     []Problem
+}
+
+func FindProblems(results []domain.Result) []Problem {
+	var problems []Problem
+	for _, result := range results {
+		if found := problemsOf(result); len(found) > 0 {
+			problems = append(problems, newProblem(result, found))
+		}
+	}
+	return problems
+}
+
+func BuildPayload(host string, results []domain.Result, at time.Time) Payload {
+	problems := FindProblems(results)
+	title := fmt.Sprintf("%d of %d repositories need attention", len(problems), len(results))
+	if host != "" {
+		title += " on " + host
+	}
+	return Payload{
+		Title:        title,
+		Host:         host,
+		GeneratedAt:  at,
+		Repositories: len(results),
+		Problems:     problems,
+	}
+}
+
+func EventPayload(host string, event Event) Payload {
+	return Payload{
+		Title:        event.Title(),
+		Host:         host,
+		GeneratedAt:  event.At,
+		Repositories: 1,
+		Problems:     []Problem{newProblem(event.Result, problemsOf(event.Result))},
+	}
+}
+
+func (p Payload) Text() string {
+	lines := []string{p.Title}
+	for _, problem := range p.Problems {
+		lines = append(lines, "• "+problem.Summary())
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (p Problem) Summary() string {
+	summary := fmt.Sprintf("%s (%s): %s, ahead %d, behind %d", p.Repo, fallback(p.Branch, "HEAD"), p.Status, p.Ahead, p.Behind)
+	if len(p.Problems) > 0 {
+		summary += " — " + strings.Join(p.Problems, ", ")
+	}
+	return summary
+}
+
+func problemsOf(result domain.Result) []string {
+	var problems []string
+	if result.HasFlag("WORKTREE_DIRTY") {
+		problems = append(problems, ProblemDirty)
+	}
+	if result.Status == domain.StatusDiverged {
+		problems = append(problems, ProblemDiverged)
+	}
+	if result.Ahead > 0 {
+		problems = append(problems, ProblemUnpushed)
+	}
+	return problems
+}
+
+func newProblem(result domain.Result, problems []string) Problem {
+	return Problem{
+		Repo:     result.RepoPath,
+		Branch:   result.Branch,
+		Status:   result.Status,
+		Ahead:    result.Ahead,
+		Behind:   result.Behind,
+		Flags:    result.Flags,
+		Problems: problems,
+	}
+}
+
+const (
+	TemplateJSON  = "json"
+	TemplateSlack = "slack"
+	TemplateTeams = "teams"
+)
+
+var builtinTemplates = map[string]string{
+	TemplateJSON:  `{{ json . }}`,
+	TemplateSlack: `{"text": {{ json .Text }}}`,
+	TemplateTeams: `{
+  "@type": "MessageCard",
+  "@context": "https://schema.org/extensions",
+  "summary": {{ json .Title }},
+  "themeColor": "D70000",
+  "title": {{ json .Title }},
+  "sections": [{"facts": [{{ range $i, $p := .Problems }}{{ if $i }}, {{ end }}{"name": {{ json $p.Repo }}, "value": {{ json $p.Summary }}}{{ end }}]}]
+}`,
+}
+
+func TemplateNames() []string {
+	return []string{TemplateJSON, TemplateSlack, TemplateTeams}
+}
+
+func BuiltinTemplate(name string) (*template.Template, error) {
+	text, ok := builtinTemplates[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown webhook template %q (want %s)", name, strings.Join(TemplateNames(), ", "))
+	}
+	return ParseTemplate(text)
+}
+
+func ParseTemplate(text string) (*template.Template, error) {
+	return template.New("webhook").Funcs(template.FuncMap{
+		"json": func(v any) (string, error) {
+			data, err := json.Marshal(v)
+			return string(data), err
+		},
+	}).Parse(text)
+}
@@ -0,0 +1,67 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrRateLimited = errors.New("notification rate limited")
+
+type RateLimited struct {
+	next      Notifier
+	perRepo   time.Duration
+	window    time.Duration
+	maxWindow int
+	now       func() time.Time
+
+	mu       sync.Mutex
+	lastSent map[string]time.Time
+	sent     []time.Time
+}
+
+func NewRateLimited(next Notifier, perRepo time.Duration, maxPerWindow int, window time.Duration) *RateLimited {
+	return &RateLimited{
+		next:      next,
+		perRepo:   perRepo,
+		window:    window,
+		maxWindow: maxPerWindow,
+		now:       time.Now,
+		lastSent:  map[string]time.Time{},
+	}
+}
+
+func (r *RateLimited) Notify(ctx context.Context, event Event) error {
+	if !r.allow(event.Repo) {
+		return ErrRateLimited
+	}
+	return r.next.Notify(ctx, event)
+}
+
+func (r *RateLimited) allow(repo string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+
+	if last, ok := r.lastSent[repo]; ok && r.perRepo > 0 && now.Sub(last) < r.perRepo {
+		return false
+	}
+
+	if r.maxWindow > 0 && r.window > 0 {
+		kept := r.sent[:0]
+		for _, t := range r.sent {
+			if now.Sub(t) < r.window {
+				kept = append(kept, t)
+			}
+		}
+		r.sent = kept
+		if len(r.sent) >= r.maxWindow {
+			return false
+		}
+		r.sent = append(r.sent, now)
+	}
+
+	r.lastSent[repo] = now
+	return true
+}
@@ -0,0 +1,72 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type fakeNotifier struct {
+	events []Event
+}
+
+func (f *fakeNotifier) Notify(_ context.Context, event Event) error {
+	f.events = append(f.events, event)
+	return nil
+}
+
+func TestRateLimitedPerRepoCooldown(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeNotifier{}
+	limited := NewRateLimited(fake, 5*time.Minute, 0, 0)
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	limited.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	if err := limited.Notify(ctx, Event{Repo: "a"}); err != nil {
+		t.Fatalf("first notify: %v", err)
+	}
+	if err := limited.Notify(ctx, Event{Repo: "a"}); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("second notify for same repo = %v, want ErrRateLimited", err)
+	}
+	if err := limited.Notify(ctx, Event{Repo: "b"}); err != nil {
+		t.Fatalf("notify for other repo: %v", err)
+	}
+
+	now = now.Add(5 * time.Minute)
+	if err := limited.Notify(ctx, Event{Repo: "a"}); err != nil {
+		t.Fatalf("notify after cooldown: %v", err)
+	}
+	if len(fake.events) != 3 {
+		t.Fatalf("delivered %d events, want 3", len(fake.events))
+	}
+}
+
+func TestRateLimitedGlobalWindow(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeNotifier{}
+	limited := NewRateLimited(fake, 0, 2, time.Hour)
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	limited.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	for _, repo := range []string{"a", "b"} {
+		if err := limited.Notify(ctx, Event{Repo: repo}); err != nil {
+			t.Fatalf("notify %s: %v", repo, err)
+		}
+	}
+	if err := limited.Notify(ctx, Event{Repo: "c"}); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("third notify = %v, want ErrRateLimited", err)
+	}
+
+	now = now.Add(time.Hour)
+	if err := limited.Notify(ctx, Event{Repo: "c"}); err != nil {
+		t.Fatalf("notify after window: %v", err)
+	}
+	if len(fake.events) != 3 {
+		t.Fatalf("delivered %d events, want 3", len(fake.events))
+	}
+}
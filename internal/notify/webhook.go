@@ -0,0 +1,131 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"text/template"
+	"time"
+)
+
+const (
+	DefaultWebhookRetries    = 3
+	DefaultWebhookBackoff    = time.Second
+	DefaultWebhookMaxBackoff = 30 * time.Second
+)
+
+type Webhook struct {
+	URL        string
+	Host       string
+	Template   *template.Template
+	Client     *http.Client
+	Retries    int
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+func NewWebhook(url string, tmpl *template.Template) *Webhook {
+	return &Webhook{
+		URL:        url,
+		Template:   tmpl,
+		Client:     &http.Client{Timeout: 10 * time.Second},
+		Retries:    DefaultWebhookRetries,
+		Backoff:    DefaultWebhookBackoff,
+		MaxBackoff: DefaultWebhookMaxBackoff,
+		sleep:      sleepContext,
+	}
+}
+
+func (w *Webhook) Notify(ctx context.Context, event Event) error {
+	return w.Send(ctx, EventPayload(w.Host, event))
+}
+
+func (w *Webhook) Send(ctx context.Context, payload Payload) error {
+	var body bytes.Buffer
+	if err := w.Template.Execute(&body, payload); err != nil {
+		return fmt.Errorf("render webhook payload: %w", err)
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= w.Retries; attempt++ {
+		if attempt > 0 {
+			if err := w.sleep(ctx, w.delay(attempt, lastErr)); err != nil {
+				return err
+			}
+		}
+		retry, err := w.post(ctx, body.Bytes())
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if !retry {
+			return err
+		}
+	}
+	return fmt.Errorf("webhook failed after %d attempts: %w", w.Retries+1, lastErr)
+}
+
+func (w *Webhook) post(ctx context.Context, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "git-sync-status")
+
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	statusErr := &StatusError{Code: resp.StatusCode, Status: resp.Status}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+		statusErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, statusErr
+}
+
+func (w *Webhook) delay(attempt int, lastErr error) time.Duration {
+	delay := w.Backoff << (attempt - 1)
+	var statusErr *StatusError
+	if errors.As(lastErr, &statusErr) && statusErr.RetryAfter > 0 {
+		delay = statusErr.RetryAfter
+	}
+	if w.MaxBackoff > 0 && (delay > w.MaxBackoff || delay <= 0) {
+		delay = w.MaxBackoff
+	}
+	return delay
+}
+
+type StatusError struct {
+	Code       int
+	Status     string
+	RetryAfter time.Duration
+}
+
+func (e *StatusError) Error() string {
+	return "webhook returned " + e.Status
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,183 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+)
+
+type webhookServer struct {
+	mu       sync.Mutex
+	statuses []int
+	bodies   [][]byte
+	headers  []http.Header
+}
+
+func (s *webhookServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bodies = append(s.bodies, body)
+	s.headers = append(s.headers, r.Header.Clone())
+	status := http.StatusOK
+	if len(s.statuses) > 0 {
+		status = s.statuses[0]
+		s.statuses = s.statuses[1:]
+	}
+	if status == http.StatusTooManyRequests {
+		w.Header().Set("Retry-After", "7")
+	}
+	w.WriteHeader(status)
+}
+
+func newTestWebhook(t *testing.T, srv *webhookServer, template string) (*Webhook, *[]time.Duration) {
+	t.Helper()
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+
+	tmpl, err := BuiltinTemplate(template)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hook := NewWebhook(ts.URL, tmpl)
+	var delays []time.Duration
+	hook.sleep = func(_ context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+	return hook, &delays
+}
+
+func testPayload() Payload {
+	return BuildPayload("build-01", []domain.Result{
+		{RepoPath: "/src/api", Branch: "main", Status: domain.StatusDiverged, Ahead: 1, Behind: 2, Flags: []string{"WORKTREE_DIRTY"}},
+		{RepoPath: "/src/web", Branch: "main", Status: domain.StatusSynced},
+	}, time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+}
+
+func TestWebhookPostsJSONPayload(t *testing.T) {
+	t.Parallel()
+
+	srv := &webhookServer{}
+	hook, delays := newTestWebhook(t, srv, TemplateJSON)
+	if err := hook.Send(context.Background(), testPayload()); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if len(srv.bodies) != 1 || len(*delays) != 0 {
+		t.Fatalf("requests = %d, delays = %v", len(srv.bodies), *delays)
+	}
+	if ct := srv.headers[0].Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+
+	var got Payload
+	if err := json.Unmarshal(srv.bodies[0], &got); err != nil {
+		t.Fatalf("decode payload: %v\n%s", err, srv.bodies[0])
+	}
+	if got.Title != "1 of 2 repositories need attention on build-01" || got.Repositories != 2 || len(got.Problems) != 1 {
+		t.Fatalf("unexpected payload: %+v", got)
+	}
+	want := []string{ProblemDirty, ProblemDiverged, ProblemUnpushed}
+	if problems := got.Problems[0].Problems; len(problems) != len(want) || problems[0] != want[0] || problems[1] != want[1] || problems[2] != want[2] {
+		t.Fatalf("Problems = %v, want %v", problems, want)
+	}
+}
+
+func TestWebhookTemplatesProduceValidJSON(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{TemplateSlack, TemplateTeams} {
+		srv := &webhookServer{}
+		hook, _ := newTestWebhook(t, srv, name)
+		if err := hook.Send(context.Background(), testPayload()); err != nil {
+			t.Fatalf("%s: Send: %v", name, err)
+		}
+		var decoded map[string]any
+		if err := json.Unmarshal(srv.bodies[0], &decoded); err != nil {
+			t.Fatalf("%s: invalid JSON: %v\n%s", name, err, srv.bodies[0])
+		}
+		if name == TemplateSlack && decoded["text"] == "" {
+			t.Fatalf("slack payload has no text: %s", srv.bodies[0])
+		}
+		if name == TemplateTeams && decoded["@type"] != "MessageCard" {
+			t.Fatalf("teams payload is not a MessageCard: %s", srv.bodies[0])
+		}
+	}
+}
+
+func TestWebhookRetriesWithBackoff(t *testing.T) {
+	t.Parallel()
+
+	srv := &webhookServer{statuses: []int{http.StatusBadGateway, http.StatusTooManyRequests, http.StatusServiceUnavailable}}
+	hook, delays := newTestWebhook(t, srv, TemplateJSON)
+	hook.Backoff = time.Second
+
+	if err := hook.Send(context.Background(), testPayload()); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if len(srv.bodies) != 4 {
+		t.Fatalf("requests = %d, want 4", len(srv.bodies))
+	}
+	want := []time.Duration{time.Second, 7 * time.Second, 4 * time.Second}
+	if len(*delays) != len(want) {
+		t.Fatalf("delays = %v, want %v", *delays, want)
+	}
+	for i := range want {
+		if (*delays)[i] != want[i] {
+			t.Fatalf("delays = %v, want %v", *delays, want)
+		}
+	}
+}
+
+func TestWebhookGivesUp(t *testing.T) {
+	t.Parallel()
+
+	srv := &webhookServer{statuses: []int{500, 500, 500}}
+	hook, _ := newTestWebhook(t, srv, TemplateJSON)
+	hook.Retries = 2
+
+	err := hook.Send(context.Background(), testPayload())
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.Code != 500 {
+		t.Fatalf("Send error = %v, want StatusError 500", err)
+	}
+	if len(srv.bodies) != 3 {
+		t.Fatalf("requests = %d, want 3", len(srv.bodies))
+	}
+}
+
+func TestWebhookDoesNotRetryClientErrors(t *testing.T) {
+	t.Parallel()
+
+	srv := &webhookServer{statuses: []int{http.StatusBadRequest}}
+	hook, delays := newTestWebhook(t, srv, TemplateJSON)
+
+	if err := hook.Send(context.Background(), testPayload()); err == nil {
+		t.Fatal("Send succeeded on 400")
+	}
+	if len(srv.bodies) != 1 || len(*delays) != 0 {
+		t.Fatalf("requests = %d, delays = %v", len(srv.bodies), *delays)
+	}
+}
+
+func TestWebhookCapsRetryAfterAtMaxBackoff(t *testing.T) {
+	t.Parallel()
+
+	srv := &webhookServer{statuses: []int{http.StatusTooManyRequests}}
+	hook, delays := newTestWebhook(t, srv, TemplateJSON)
+	hook.MaxBackoff = 5 * time.Second
+	if err := hook.Send(context.Background(), testPayload()); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if len(*delays) != 1 || (*delays)[0] != 5*time.Second {
+		t.Fatalf("delays = %v, want [5s]", *delays)
+	}
+}
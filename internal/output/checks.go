@@ -0,0 +1,80 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+	"github.com/guionardo/git_sync_status/internal/service"
+)
+
+type check struct {
+	Suite    string
+	Name     string
+	Status   domain.Status
+	Ahead    int
+	Behind   int
+	Flags    []string
+	Details  []string
+	Findings []domain.RiskFinding
+}
+
+func (c check) Failed() bool {
+	if c.Status != domain.StatusSynced {
+		return true
+	}
+	for _, flag := range c.Flags {
+		if flag == "WORKTREE_DIRTY" {
+			return true
+		}
+	}
+	return false
+}
+
+func (c check) Summary() string {
+	parts := []string{fmt.Sprintf("status=%s ahead=%d behind=%d", c.Status, c.Ahead, c.Behind)}
+	if len(c.Flags) > 0 {
+		parts = append(parts, "flags="+strings.Join(c.Flags, ","))
+	}
+	return strings.Join(parts, " ")
+}
+
+func toCheck(record any) (check, error) {
+	switch r := record.(type) {
+	case domain.Result:
+		return check{
+			Suite: r.RepoPath, Name: fallbackName(r.Branch), Status: r.Status,
+			Ahead: r.Ahead, Behind: r.Behind, Flags: r.Flags,
+			Details: append(append([]string{}, r.Details...), r.Actions...), Findings: r.Findings,
+		}, nil
+	case *domain.Result:
+		return toCheck(*r)
+	case service.BranchStatus:
+		details := append([]string{}, r.Details...)
+		if r.Suggestion != "" {
+			details = append(details, r.Suggestion)
+		}
+		return check{
+			Suite: fallbackSuite(r.RepoPath), Name: r.Branch, Status: r.Status,
+			Ahead: r.Ahead, Behind: r.Behind, Flags: r.Flags, Details: details,
+		}, nil
+	case *service.BranchStatus:
+		return toCheck(*r)
+	default:
+		return check{}, fmt.Errorf("unsupported record type %T (expected a status result or branch row)", record)
+	}
+}
+
+func fallbackSuite(repoPath string) string {
+	if strings.TrimSpace(repoPath) == "" {
+		return "branches"
+	}
+	return repoPath
+}
+
+func fallbackName(branch string) string {
+	if strings.TrimSpace(branch) == "" {
+		return "HEAD"
+	}
+	return branch
+}
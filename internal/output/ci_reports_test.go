@@ -0,0 +1,114 @@
+package output
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+	"github.com/guionardo/git_sync_status/internal/service"
+)
+
+func TestJUnitFailsUnsyncedOrDirtyCases(t *testing.T) {
+	t.Parallel()
+
+	out := render(t, "junit", []domain.Result{
+		{RepoPath: "/src/api", Branch: "main", Status: domain.StatusSynced},
+		{RepoPath: "/src/web", Branch: "main", Status: domain.StatusSynced, Flags: []string{"WORKTREE_DIRTY"}},
+		{RepoPath: "/src/web", Branch: "dev", Status: domain.StatusDiverged, Ahead: 1, Behind: 2, Actions: []string{"git pull --rebase"}},
+	})
+
+	var doc junitSuites
+	if err := xml.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("junit output unreadable: %v\n%s", err, out)
+	}
+	if doc.Tests != 3 || doc.Failures != 2 || len(doc.Suites) != 2 {
+		t.Fatalf("tests=%d failures=%d suites=%d, want 3/2/2", doc.Tests, doc.Failures, len(doc.Suites))
+	}
+	web := doc.Suites[1]
+	if web.Name != "/src/web" || web.Failures != 2 {
+		t.Fatalf("unexpected suite %+v", web)
+	}
+	if f := web.Cases[1].Failure; f == nil || f.Type != "DIVERGED" || !strings.Contains(f.Body, "git pull --rebase") {
+		t.Fatalf("unexpected failure %+v", f)
+	}
+	if doc.Suites[0].Cases[0].Failure != nil {
+		t.Fatal("synced clean repo should pass")
+	}
+}
+
+func TestJUnitAcceptsBranchRows(t *testing.T) {
+	t.Parallel()
+
+	var buf strings.Builder
+	r, _ := New("junit", &buf)
+	if err := RenderAll(r, []service.BranchStatus{{RepoPath: "/src/api", Branch: "feature", Status: domain.StatusLate, Behind: 3}}); err != nil {
+		t.Fatalf("RenderAll() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), `<testsuite name="/src/api"`) || !strings.Contains(buf.String(), `<testcase classname="/src/api" name="feature">`) {
+		t.Fatalf("unexpected output:\n%s", buf.String())
+	}
+	buf.Reset()
+
+	r, _ = New("junit", &buf)
+	if err := r.Write("not a result"); err == nil {
+		t.Fatal("expected error for unsupported record")
+	}
+}
+
+func TestSARIFReportsFlagsAndFindings(t *testing.T) {
+	t.Parallel()
+
+	var buf strings.Builder
+	r := &sarifRenderer{w: &buf, rules: map[string]bool{}, root: "/src"}
+	err := RenderAll(r, []domain.Result{
+		{RepoPath: "/src/api", Branch: "main", Status: domain.StatusSynced},
+		{
+			RepoPath: "/src/web", Branch: "main", Status: domain.StatusSyncPending, Ahead: 1,
+			Flags:    []string{"RISKY_PUSH"},
+			Findings: []domain.RiskFinding{{Rule: "PRIVATE_KEY", Commit: "abc1234", Path: "deploy/id_rsa", Detail: "private key"}},
+		},
+		{RepoPath: "/elsewhere/tools", Branch: "main", Status: domain.StatusLate, Behind: 1},
+	})
+	if err != nil {
+		t.Fatalf("RenderAll() error = %v", err)
+	}
+	out := buf.String()
+
+	var doc sarifLog
+	if err := json.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("sarif output unreadable: %v", err)
+	}
+	if doc.Version != "2.1.0" || len(doc.Runs) != 1 {
+		t.Fatalf("unexpected sarif envelope: %+v", doc)
+	}
+	run := doc.Runs[0]
+	var ids []string
+	for _, rule := range run.Tool.Driver.Rules {
+		ids = append(ids, rule.ID)
+	}
+	if strings.Join(ids, ",") != "LATE,PRIVATE_KEY,RISKY_PUSH,SYNC_PENDING" {
+		t.Fatalf("rules = %v", ids)
+	}
+	if len(run.Results) != 4 {
+		t.Fatalf("got %d results, want 4", len(run.Results))
+	}
+	if base := run.OriginalURIBaseIDs[sarifSrcRoot].URI; base != "file:///src/" {
+		t.Fatalf("originalUriBaseIds = %+v", run.OriginalURIBaseIDs)
+	}
+	finding := run.Results[2]
+	if loc := finding.Locations[0].PhysicalLocation.ArtifactLocation; finding.RuleID != "PRIVATE_KEY" || finding.Level != "error" ||
+		loc.URI != "web/deploy/id_rsa" || loc.URIBaseID != sarifSrcRoot {
+		t.Fatalf("unexpected finding result %+v", finding)
+	}
+	if loc := run.Results[0].Locations[0].PhysicalLocation.ArtifactLocation; loc.URI != "web/" || loc.URIBaseID != sarifSrcRoot {
+		t.Fatalf("unexpected repository location %+v", loc)
+	}
+	if loc := run.Results[3].Locations[0].PhysicalLocation.ArtifactLocation; loc.URI != "file:///elsewhere/tools/" || loc.URIBaseID != "" {
+		t.Fatalf("repository outside %%SRCROOT%% = %+v, want absolute URI", loc)
+	}
+	if run.Results[0].Level != "warning" || run.Results[1].Level != "error" {
+		t.Fatalf("unexpected levels: %s, %s", run.Results[0].Level, run.Results[1].Level)
+	}
+}
@@ -0,0 +1,127 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func init() {
+	Register("csv", func(w io.Writer) Renderer { return newDelimitedRenderer(w, ',') })
+	Register("tsv", func(w io.Writer) Renderer { return newDelimitedRenderer(w, '\t') })
+}
+
+type delimitedRenderer struct {
+	w      *csv.Writer
+	header []string
+}
+
+func newDelimitedRenderer(w io.Writer, comma rune) *delimitedRenderer {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	return &delimitedRenderer{w: cw}
+}
+
+func (r *delimitedRenderer) Write(record any) error {
+	names, values, err := flatten(record)
+	if err != nil {
+		return err
+	}
+	if r.header == nil {
+		r.header = names
+		if err := r.w.Write(names); err != nil {
+			return err
+		}
+	}
+	if err := r.w.Write(values); err != nil {
+		return err
+	}
+	r.w.Flush()
+	return r.w.Error()
+}
+
+func (r *delimitedRenderer) Close() error {
+	r.w.Flush()
+	return r.w.Error()
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func flatten(record any) ([]string, []string, error) {
+	v := reflect.ValueOf(record)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, nil, fmt.Errorf("cannot render nil record")
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		s, err := cell(v)
+		return []string{"value"}, []string{s}, err
+	}
+
+	var names, values []string
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		s, err := cell(v.Field(i))
+		if err != nil {
+			return nil, nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		names = append(names, field.Name)
+		values = append(values, s)
+	}
+	return names, values, nil
+}
+
+func cell(v reflect.Value) (string, error) {
+	if v.Type() == timeType {
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return "", nil
+		}
+		return t.Format(time.RFC3339), nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), nil
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return "", nil
+		}
+	case reflect.Slice:
+		if v.Len() == 0 {
+			return "", nil
+		}
+		if v.Type().Elem().Kind() == reflect.String {
+			parts := make([]string, v.Len())
+			for i := range parts {
+				parts[i] = v.Index(i).String()
+			}
+			return strings.Join(parts, ";"), nil
+		}
+	}
+
+	body, err := json.Marshal(v.Interface())
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
@@ -0,0 +1,42 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+)
+
+func init() {
+	Register("json", func(w io.Writer) Renderer { return &jsonRenderer{w: w} })
+	Register("ndjson", func(w io.Writer) Renderer { return &ndjsonRenderer{enc: json.NewEncoder(w)} })
+}
+
+type jsonRenderer struct {
+	w       io.Writer
+	records []any
+}
+
+func (r *jsonRenderer) Write(record any) error {
+	r.records = append(r.records, record)
+	return nil
+}
+
+func (r *jsonRenderer) Close() error {
+	if r.records == nil {
+		r.records = []any{}
+	}
+	enc := json.NewEncoder(r.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r.records)
+}
+
+type ndjsonRenderer struct {
+	enc *json.Encoder
+}
+
+func (r *ndjsonRenderer) Write(record any) error {
+	return r.enc.Encode(record)
+}
+
+func (r *ndjsonRenderer) Close() error {
+	return nil
+}
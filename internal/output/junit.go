@@ -0,0 +1,89 @@
+package output
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+func init() {
+	Register("junit", func(w io.Writer) Renderer { return &junitRenderer{w: w} })
+}
+
+type junitRenderer struct {
+	w      io.Writer
+	suites []*junitSuite
+	index  map[string]*junitSuite
+}
+
+type junitSuites struct {
+	XMLName  xml.Name      `xml:"testsuites"`
+	Name     string        `xml:"name,attr"`
+	Tests    int           `xml:"tests,attr"`
+	Failures int           `xml:"failures,attr"`
+	Suites   []*junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+func (r *junitRenderer) Write(record any) error {
+	c, err := toCheck(record)
+	if err != nil {
+		return err
+	}
+	if r.index == nil {
+		r.index = map[string]*junitSuite{}
+	}
+	suite, ok := r.index[c.Suite]
+	if !ok {
+		suite = &junitSuite{Name: c.Suite}
+		r.index[c.Suite] = suite
+		r.suites = append(r.suites, suite)
+	}
+
+	tc := junitCase{ClassName: c.Suite, Name: c.Name, SystemOut: c.Summary()}
+	if c.Failed() {
+		body := append([]string{c.Summary()}, c.Details...)
+		tc.Failure = &junitFailure{Message: c.Summary(), Type: string(c.Status), Body: strings.Join(body, "\n")}
+		suite.Failures++
+	}
+	suite.Tests++
+	suite.Cases = append(suite.Cases, tc)
+	return nil
+}
+
+func (r *junitRenderer) Close() error {
+	doc := junitSuites{Name: "git-sync-status", Suites: r.suites}
+	for _, suite := range r.suites {
+		doc.Tests += suite.Tests
+		doc.Failures += suite.Failures
+	}
+	if _, err := io.WriteString(r.w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(r.w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(r.w, "\n")
+	return err
+}
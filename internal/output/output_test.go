@@ -0,0 +1,157 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+)
+
+var sampleResults = []domain.Result{
+	{
+		RepoPath: "/src/api", Branch: "main", Status: domain.StatusDiverged, Ahead: 2, Behind: 1,
+		Flags: []string{"WORKTREE_DIRTY", "WILL_CONFLICT"},
+		Tags:  &domain.TagStatus{LocalOnly: []string{"v1.0.0"}},
+	},
+	{RepoPath: "/src/docs, old", Status: domain.StatusNoRemote},
+}
+
+func render(t *testing.T, name string, records []domain.Result) string {
+	t.Helper()
+	var buf bytes.Buffer
+	r, err := New(name, &buf)
+	if err != nil {
+		t.Fatalf("New(%q) error = %v", name, err)
+	}
+	if err := RenderAll(r, records); err != nil {
+		t.Fatalf("RenderAll(%q) error = %v", name, err)
+	}
+	return buf.String()
+}
+
+func TestNewRejectsUnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	_, err := New("xml", &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "ndjson") {
+		t.Fatalf("New(xml) error = %v, want list of available formats", err)
+	}
+}
+
+func TestNDJSONWritesOneRecordPerLine(t *testing.T) {
+	t.Parallel()
+
+	lines := strings.Split(strings.TrimSpace(render(t, "ndjson", sampleResults)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2", len(lines))
+	}
+	var got domain.Result
+	if err := json.Unmarshal([]byte(lines[0]), &got); err != nil {
+		t.Fatalf("line is not JSON: %v", err)
+	}
+	if got.Branch != "main" || got.Ahead != 2 || got.Tags == nil {
+		t.Fatalf("decoded %+v", got)
+	}
+}
+
+func TestJSONWritesArray(t *testing.T) {
+	t.Parallel()
+
+	if got := render(t, "json", nil); strings.TrimSpace(got) != "[]" {
+		t.Fatalf("empty json output = %q, want []", got)
+	}
+	var got []domain.Result
+	if err := json.Unmarshal([]byte(render(t, "json", sampleResults)), &got); err != nil || len(got) != 2 {
+		t.Fatalf("json output decoded to %d records, err %v", len(got), err)
+	}
+}
+
+func TestDelimitedFlattensFields(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		name  string
+		comma rune
+	}{{"csv", ','}, {"tsv", '\t'}} {
+		reader := csv.NewReader(strings.NewReader(render(t, tt.name, sampleResults)))
+		reader.Comma = tt.comma
+		rows, err := reader.ReadAll()
+		if err != nil {
+			t.Fatalf("%s output unreadable: %v", tt.name, err)
+		}
+		if len(rows) != 3 {
+			t.Fatalf("%s: got %d rows, want header + 2", tt.name, len(rows))
+		}
+		col := map[string]int{}
+		for i, name := range rows[0] {
+			col[name] = i
+		}
+		if rows[1][col["Flags"]] != "WORKTREE_DIRTY;WILL_CONFLICT" || rows[1][col["Ahead"]] != "2" {
+			t.Fatalf("%s: unexpected row %v", tt.name, rows[1])
+		}
+		if !strings.Contains(rows[1][col["Tags"]], `"LocalOnly":["v1.0.0"]`) || rows[2][col["Tags"]] != "" {
+			t.Fatalf("%s: unexpected nested Tags cells %q / %q", tt.name, rows[1][col["Tags"]], rows[2][col["Tags"]])
+		}
+		if rows[2][col["RepoPath"]] != "/src/docs, old" {
+			t.Fatalf("%s: RepoPath = %q", tt.name, rows[2][col["RepoPath"]])
+		}
+	}
+}
+
+func TestDelimitedFormatsTimes(t *testing.T) {
+	t.Parallel()
+
+	type row struct {
+		Name string
+		When time.Time
+		Zero time.Time
+	}
+	var buf bytes.Buffer
+	r, _ := New("csv", &buf)
+	if err := RenderAll(r, []row{{Name: "a", When: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}}); err != nil {
+		t.Fatal(err)
+	}
+	if want := "Name,When,Zero\na,2024-05-01T12:00:00Z,\n"; buf.String() != want {
+		t.Fatalf("csv = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestYAMLUsesJSONFieldNames(t *testing.T) {
+	t.Parallel()
+
+	out := render(t, "yaml", sampleResults)
+	if !strings.Contains(out, "RepoPath: /src/api") {
+		t.Fatalf("yaml output missing JSON field names:\n%s", out)
+	}
+	var got []domain.Result
+	if err := yaml.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("yaml output unreadable: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("yaml output decoded to %d records", len(got))
+	}
+	if got := render(t, "yaml", nil); got != "[]\n" {
+		t.Fatalf("empty yaml output = %q", got)
+	}
+}
+
+func TestTOMLWritesArrayOfTables(t *testing.T) {
+	t.Parallel()
+
+	var got struct {
+		Records []domain.Result `toml:"records"`
+	}
+	if _, err := toml.Decode(render(t, "toml", sampleResults), &got); err != nil {
+		t.Fatalf("toml output unreadable: %v", err)
+	}
+	if len(got.Records) != 2 || got.Records[0].Ahead != 2 || got.Records[0].Tags == nil || got.Records[1].RepoPath != "/src/docs, old" {
+		t.Fatalf("toml decoded to %+v", got.Records)
+	}
+}
@@ -0,0 +1,57 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type Renderer interface {
+	Write(record any) error
+	Close() error
+}
+
+type Factory func(w io.Writer) Renderer
+
+var (
+	mu        sync.RWMutex
+	factories = map[string]Factory{}
+)
+
+func Register(name string, factory Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+	factories[strings.ToLower(name)] = factory
+}
+
+func New(name string, w io.Writer) (Renderer, error) {
+	mu.RLock()
+	factory, ok := factories[strings.ToLower(name)]
+	mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown output format %q (available: %s)", name, strings.Join(Names(), ", "))
+	}
+	return factory(w), nil
+}
+
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func RenderAll[T any](r Renderer, records []T) error {
+	for _, record := range records {
+		if err := r.Write(record); err != nil {
+			return err
+		}
+	}
+	return r.Close()
+}
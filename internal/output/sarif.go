@@ -0,0 +1,244 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifSrcRoot = "%SRCROOT%"
+)
+
+var sarifRules = map[string]string{
+	string(domain.StatusNotAGitRepo): "Path is not a git repository",
+	string(domain.StatusNoRemote):    "Repository has no remote",
+	string(domain.StatusNoUpstream):  "Branch has no upstream",
+	string(domain.StatusDetached):    "HEAD is detached",
+	string(domain.StatusSyncPending): "Local commits are not pushed",
+	string(domain.StatusLate):        "Branch is behind its upstream",
+	string(domain.StatusDiverged):    "Branch has diverged from its upstream",
+	"WORKTREE_DIRTY":                 "Working tree has staged, unstaged or untracked changes",
+	"DETACHED_HEAD":                  "Repository is not on a branch",
+	"REMOTE_UNREACHABLE":             "Remote could not be fetched",
+	"SUBMODULES_OUT_OF_SYNC":         "A submodule is uninitialized, moved or modified",
+	"CHECKED_OUT_ELSEWHERE":          "Branch is checked out in another worktree",
+	"LFS_UNPUSHED":                   "Git LFS objects are missing on the remote",
+	"TAGS_UNPUSHED":                  "Local tags do not exist on the remote",
+	"TAGS_MISSING_LOCALLY":           "Remote tags were never fetched",
+	"TAGS_MISMATCHED":                "Tags point to different commits locally and on the remote",
+	"UNSIGNED_COMMITS":               "Outgoing commits are not signed",
+	"FOREIGN_AUTHOR":                 "Outgoing commits have an unexpected author or committer",
+	"RISKY_PUSH":                     "Outgoing commits contain large files or secrets",
+	"WILL_CONFLICT":                  "Pulling is predicted to conflict",
+	"STALE":                          "Branch has no recent commits",
+	"LARGE_FILE":                     "Outgoing commit adds a large file",
+	"ENV_FILE":                       "Outgoing commit adds an environment file",
+	"PRIVATE_KEY":                    "Outgoing commit adds a private key",
+	"AWS_ACCESS_KEY":                 "Outgoing commit adds an AWS access key",
+	"AWS_SECRET_KEY":                 "Outgoing commit adds an AWS secret key",
+}
+
+var sarifErrors = map[string]bool{
+	string(domain.StatusNotAGitRepo): true,
+	string(domain.StatusDiverged):    true,
+	"RISKY_PUSH":                     true,
+	"WILL_CONFLICT":                  true,
+	"UNSIGNED_COMMITS":               true,
+	"FOREIGN_AUTHOR":                 true,
+}
+
+func init() {
+	Register("sarif", func(w io.Writer) Renderer {
+		root, _ := os.Getwd()
+		return &sarifRenderer{w: w, rules: map[string]bool{}, root: root}
+	})
+}
+
+type sarifRenderer struct {
+	w       io.Writer
+	root    string
+	results []sarifResult
+	rules   map[string]bool
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool               sarifTool                        `json:"tool"`
+	OriginalURIBaseIDs map[string]sarifArtifactLocation `json:"originalUriBaseIds,omitempty"`
+	Results            []sarifResult                    `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+func (r *sarifRenderer) Write(record any) error {
+	c, err := toCheck(record)
+	if err != nil {
+		return err
+	}
+	subject := c.Name
+	if c.Suite != "branches" {
+		subject = fmt.Sprintf("%s (%s)", c.Suite, c.Name)
+	}
+
+	if c.Status != domain.StatusSynced {
+		r.add(string(c.Status), fmt.Sprintf("%s: %s", subject, c.Summary()), c.Suite, "")
+	}
+	for _, flag := range c.Flags {
+		r.add(flag, fmt.Sprintf("%s: %s", subject, flag), c.Suite, "")
+	}
+	for _, f := range c.Findings {
+		r.add(f.Rule, fmt.Sprintf("%s: %s in commit %s (%s)", subject, f.Path, f.Commit, f.Detail), c.Suite, f.Path)
+	}
+	return nil
+}
+
+func (r *sarifRenderer) add(ruleID string, message string, repoPath string, file string) {
+	level := "warning"
+	if sarifErrors[ruleID] || file != "" {
+		level = "error"
+	}
+	r.rules[ruleID] = true
+	r.results = append(r.results, sarifResult{
+		RuleID:    ruleID,
+		Level:     level,
+		Message:   sarifMessage{Text: message},
+		Locations: r.locations(repoPath, file),
+	})
+}
+
+func (r *sarifRenderer) locations(repoPath string, file string) []sarifLocation {
+	if repoPath == "" || repoPath == "branches" {
+		return nil
+	}
+	path := repoPath
+	if file != "" {
+		path = filepath.Join(repoPath, file)
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	artifact := sarifArtifactLocation{URI: fileURI(path, file == "")}
+	if rel, ok := relativeTo(r.root, path); ok {
+		if file == "" {
+			rel += "/"
+		}
+		artifact = sarifArtifactLocation{URI: (&url.URL{Path: rel}).String(), URIBaseID: sarifSrcRoot}
+	}
+	return []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: artifact}}}
+}
+
+func relativeTo(root string, path string) (string, bool) {
+	if root == "" {
+		return "", false
+	}
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	if rel == "." {
+		return ".", true
+	}
+	return filepath.ToSlash(rel), true
+}
+
+func fileURI(path string, dir bool) string {
+	path = filepath.ToSlash(path)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	if dir && !strings.HasSuffix(path, "/") {
+		path += "/"
+	}
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
+
+func (r *sarifRenderer) Close() error {
+	ids := make([]string, 0, len(r.rules))
+	for id := range r.rules {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	rules := make([]sarifRule, 0, len(ids))
+	for _, id := range ids {
+		text, ok := sarifRules[id]
+		if !ok {
+			text = id
+		}
+		rules = append(rules, sarifRule{ID: id, ShortDescription: sarifMessage{Text: text}})
+	}
+
+	results := r.results
+	if results == nil {
+		results = []sarifResult{}
+	}
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "git-sync-status",
+			InformationURI: "https://github.com/guionardo/git_sync_status",
+			Rules:          rules,
+		}},
+		Results: results,
+	}
+	if r.root != "" {
+		run.OriginalURIBaseIDs = map[string]sarifArtifactLocation{sarifSrcRoot: {URI: fileURI(r.root, true)}}
+	}
+	doc := sarifLog{
+		Schema:  sarifSchema,
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	}
+	enc := json.NewEncoder(r.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
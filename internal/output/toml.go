@@ -0,0 +1,39 @@
+package output
+
+import (
+	"io"
+	"reflect"
+
+	"github.com/BurntSushi/toml"
+)
+
+func init() {
+	Register("toml", func(w io.Writer) Renderer { return &tomlRenderer{w: w} })
+}
+
+type tomlRenderer struct {
+	w io.Writer
+}
+
+type tomlRecords struct {
+	Records []any `toml:"records"`
+}
+
+type tomlValue struct {
+	Value any `toml:"value"`
+}
+
+func (r *tomlRenderer) Write(record any) error {
+	v := reflect.ValueOf(record)
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		record = tomlValue{Value: record}
+	}
+	return toml.NewEncoder(r.w).Encode(tomlRecords{Records: []any{record}})
+}
+
+func (r *tomlRenderer) Close() error {
+	return nil
+}
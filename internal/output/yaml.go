@@ -0,0 +1,60 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	Register("yaml", func(w io.Writer) Renderer { return &yamlRenderer{w: w} })
+}
+
+type yamlRenderer struct {
+	w     io.Writer
+	wrote bool
+}
+
+func (r *yamlRenderer) Write(record any) error {
+	node, err := jsonNode(record)
+	if err != nil {
+		return err
+	}
+	body, err := yaml.Marshal([]*yaml.Node{node})
+	if err != nil {
+		return err
+	}
+	r.wrote = true
+	_, err = r.w.Write(body)
+	return err
+}
+
+func (r *yamlRenderer) Close() error {
+	if r.wrote {
+		return nil
+	}
+	_, err := io.WriteString(r.w, "[]\n")
+	return err
+}
+
+func jsonNode(record any) (*yaml.Node, error) {
+	body, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(body, &doc); err != nil {
+		return nil, err
+	}
+	node := doc.Content[0]
+	resetStyle(node)
+	return node, nil
+}
+
+func resetStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
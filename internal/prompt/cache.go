@@ -0,0 +1,97 @@
+package prompt
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/gitdir"
+)
+
+const (
+	cacheDir  = "sync-status"
+	cacheFile = "prompt.json"
+	lockFile  = "prompt.lock"
+	lockTTL   = 2 * time.Minute
+)
+
+type Repo struct {
+	gitdir.Repo
+}
+
+func FindRepo(path string) (Repo, error) {
+	repo, err := gitdir.Find(path)
+	return Repo{Repo: repo}, err
+}
+
+func (r Repo) ReadCache() (Segment, bool) {
+	body, err := os.ReadFile(filepath.Join(r.GitDir, cacheDir, cacheFile))
+	if err != nil {
+		return Segment{}, false
+	}
+	var seg Segment
+	if err := json.Unmarshal(body, &seg); err != nil {
+		return Segment{}, false
+	}
+	return seg, true
+}
+
+func (r Repo) WriteCache(seg Segment) error {
+	dir := filepath.Join(r.GitDir, cacheDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	body, err := json.Marshal(seg)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, cacheFile+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(body); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(dir, cacheFile))
+}
+
+func (r Repo) NeedsRefresh(seg Segment, cached bool, ttl time.Duration, now time.Time) bool {
+	if !cached || seg.Branch != r.Head() || now.Sub(seg.UpdatedAt) > ttl {
+		return true
+	}
+	for _, name := range []string{"HEAD", "index"} {
+		info, err := os.Stat(filepath.Join(r.GitDir, name))
+		if err == nil && info.ModTime().After(seg.UpdatedAt) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Repo) TryLock(now time.Time) bool {
+	dir := filepath.Join(r.GitDir, cacheDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return false
+	}
+	path := filepath.Join(dir, lockFile)
+	if info, err := os.Stat(path); err == nil && now.Sub(info.ModTime()) > lockTTL {
+		os.Remove(path)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return false
+	}
+	f.Close()
+	return true
+}
+
+func (r Repo) Unlock() {
+	os.Remove(filepath.Join(r.GitDir, cacheDir, lockFile))
+}
@@ -0,0 +1,64 @@
+package prompt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCacheRoundTripAndRefreshDecision(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	mustWrite(t, filepath.Join(root, ".git", "HEAD"), "ref: refs/heads/main\n")
+	repo, err := FindRepo(root)
+	if err != nil {
+		t.Fatalf("FindRepo() error = %v", err)
+	}
+
+	now := time.Now().Add(time.Hour)
+	if _, ok := repo.ReadCache(); ok {
+		t.Fatal("expected empty cache")
+	}
+	if err := repo.WriteCache(Segment{Branch: "main", Ahead: 3, UpdatedAt: now}); err != nil {
+		t.Fatalf("WriteCache() error = %v", err)
+	}
+	seg, ok := repo.ReadCache()
+	if !ok || seg.Ahead != 3 {
+		t.Fatalf("ReadCache() = %+v, %v", seg, ok)
+	}
+
+	if repo.NeedsRefresh(seg, true, time.Minute, now.Add(time.Second)) {
+		t.Fatal("fresh cache should not need a refresh")
+	}
+	if !repo.NeedsRefresh(seg, true, time.Minute, now.Add(2*time.Minute)) {
+		t.Fatal("expired cache should need a refresh")
+	}
+	seg.Branch = "other"
+	if !repo.NeedsRefresh(seg, true, time.Minute, now) {
+		t.Fatal("cache for another branch should need a refresh")
+	}
+
+	lockedAt := time.Now()
+	if !repo.TryLock(lockedAt) || repo.TryLock(lockedAt) {
+		t.Fatal("lock should be taken exactly once")
+	}
+	if !repo.TryLock(lockedAt.Add(3 * time.Minute)) {
+		t.Fatal("abandoned lock should be reclaimed")
+	}
+	repo.Unlock()
+	if !repo.TryLock(lockedAt) {
+		t.Fatal("lock should be available after Unlock")
+	}
+}
+
+func mustWrite(t *testing.T, path string, body string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,74 @@
+package prompt
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+)
+
+const DefaultFormat = "%b %a %B %d"
+
+type Segment struct {
+	Branch    string
+	Status    domain.Status
+	Ahead     int
+	Behind    int
+	Dirty     bool
+	Flags     []string
+	UpdatedAt time.Time
+}
+
+func FromResult(result domain.Result, now time.Time) Segment {
+	return Segment{
+		Branch:    result.Branch,
+		Status:    result.Status,
+		Ahead:     result.Ahead,
+		Behind:    result.Behind,
+		Dirty:     result.HasFlag("WORKTREE_DIRTY"),
+		Flags:     result.Flags,
+		UpdatedAt: now,
+	}
+}
+
+func Render(format string, seg Segment) string {
+	if format == "" {
+		format = DefaultFormat
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' || i+1 == len(format) {
+			b.WriteByte(format[i])
+			continue
+		}
+		i++
+		switch format[i] {
+		case 'b':
+			b.WriteString(seg.Branch)
+		case 's':
+			b.WriteString(string(seg.Status))
+		case 'a':
+			if seg.Ahead > 0 {
+				fmt.Fprintf(&b, "↑%d", seg.Ahead)
+			}
+		case 'B':
+			if seg.Behind > 0 {
+				fmt.Fprintf(&b, "↓%d", seg.Behind)
+			}
+		case 'd':
+			if seg.Dirty {
+				b.WriteString("✚")
+			}
+		case 'f':
+			b.WriteString(strings.Join(seg.Flags, ","))
+		case '%':
+			b.WriteByte('%')
+		default:
+			b.WriteByte('%')
+			b.WriteByte(format[i])
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
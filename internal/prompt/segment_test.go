@@ -0,0 +1,47 @@
+package prompt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+)
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	seg := FromResult(domain.Result{
+		Branch: "main", Status: domain.StatusDiverged, Ahead: 2, Behind: 1,
+		Flags: []string{"WORKTREE_DIRTY", "STALE"},
+	}, time.Now())
+
+	tests := []struct {
+		format string
+		seg    Segment
+		want   string
+	}{
+		{format: "", seg: seg, want: "main ↑2 ↓1 ✚"},
+		{format: "%b %a %B %d", seg: Segment{Branch: "main"}, want: "main"},
+		{format: "%b:%s [%f] 100%%", seg: seg, want: "main:DIVERGED [WORKTREE_DIRTY,STALE] 100%"},
+		{format: "%x %", seg: seg, want: "%x %"},
+	}
+
+	for _, tt := range tests {
+		if got := Render(tt.format, tt.seg); got != tt.want {
+			t.Fatalf("Render(%q) = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestSnippetRejectsUnknownShell(t *testing.T) {
+	t.Parallel()
+
+	for _, shell := range Shells {
+		if _, err := Snippet(shell, "/usr/bin/git-sync-status"); err != nil {
+			t.Fatalf("Snippet(%q) error = %v", shell, err)
+		}
+	}
+	if _, err := Snippet("tcsh", "git-sync-status"); err == nil {
+		t.Fatal("expected error for unsupported shell")
+	}
+}
@@ -0,0 +1,44 @@
+package prompt
+
+import (
+	"fmt"
+	"strings"
+)
+
+var Shells = []string{"bash", "zsh", "fish", "starship"}
+
+func Snippet(shell string, binary string) (string, error) {
+	quoted := "'" + strings.ReplaceAll(binary, "'", `'\''`) + "'"
+	switch shell {
+	case "bash":
+		return fmt.Sprintf(`__git_sync_status_ps1() {
+  local segment
+  segment=$(%s prompt 2>/dev/null) && [ -n "$segment" ] && printf ' (%%s)' "$segment"
+}
+PS1='\w$(__git_sync_status_ps1)\$ '
+`, quoted), nil
+	case "zsh":
+		return fmt.Sprintf(`setopt PROMPT_SUBST
+__git_sync_status_prompt() {
+  %s prompt 2>/dev/null
+}
+RPROMPT='$(__git_sync_status_prompt)'
+`, quoted), nil
+	case "fish":
+		return fmt.Sprintf(`function fish_right_prompt
+    %s prompt 2>/dev/null
+end
+`, quoted), nil
+	case "starship":
+		return fmt.Sprintf(`# add to ~/.config/starship.toml
+[custom.git_sync_status]
+command = "%s prompt"
+require_repo = true
+shell = ["sh"]
+format = "[$output]($style) "
+style = "bold yellow"
+`, strings.ReplaceAll(binary, `"`, `\"`)), nil
+	default:
+		return "", fmt.Errorf("unsupported shell %q (supported: %s)", shell, strings.Join(Shells, ", "))
+	}
+}
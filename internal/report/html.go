@@ -0,0 +1,31 @@
+package report
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+	"github.com/guionardo/git_sync_status/internal/service"
+	"github.com/guionardo/git_sync_status/internal/tui"
+)
+
+//go:embed report.html.tmpl
+var htmlSource string
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"severity": func(status domain.Status) string { return status.Severity() },
+	"cells":    tui.BranchTableCells,
+	"flags":    joinFlags,
+	"orDash":   func(value string) string { return fallback(value, "-") },
+}).Parse(htmlSource))
+
+type htmlData struct {
+	service.Report
+	Header         []string
+	NeedsAttention int
+}
+
+func HTML(w io.Writer, r service.Report) error {
+	return htmlTemplate.Execute(w, htmlData{Report: r, Header: tui.BranchTableHeader, NeedsAttention: needsAttention(r)})
+}
@@ -0,0 +1,102 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+	"github.com/guionardo/git_sync_status/internal/service"
+	"github.com/guionardo/git_sync_status/internal/tui"
+)
+
+var severityIcons = map[string]string{
+	domain.SeverityOK:    "✅",
+	domain.SeverityWarn:  "⚠️",
+	domain.SeverityError: "❌",
+}
+
+func Markdown(r service.Report) string {
+	var b strings.Builder
+	b.WriteString("# Git sync status report\n\n")
+	fmt.Fprintf(&b, "Generated %s · %d repositories · %d need attention\n\n",
+		r.GeneratedAt.Format("2006-01-02 15:04 MST"), len(r.Repositories), needsAttention(r))
+
+	b.WriteString("## Summary\n\n")
+	b.WriteString("| Repository | Branch | Status | A/B | Flags |\n")
+	b.WriteString("| --- | --- | --- | --- | --- |\n")
+	for _, repo := range r.Repositories {
+		res := repo.Result
+		fmt.Fprintf(&b, "| %s | %s | %s | %d/%d | %s |\n",
+			cell(res.RepoPath), cell(fallback(res.Branch, "-")), statusCell(res.Status),
+			res.Ahead, res.Behind, cell(joinFlags(res.Flags)))
+	}
+
+	for _, repo := range r.Repositories {
+		res := repo.Result
+		fmt.Fprintf(&b, "\n## %s\n\n", res.RepoPath)
+		fmt.Fprintf(&b, "%s `%s`", statusCell(res.Status), fallback(res.Branch, "-"))
+		if res.Upstream != "" {
+			fmt.Fprintf(&b, " → `%s`", res.Upstream)
+		}
+		fmt.Fprintf(&b, " (ahead %d, behind %d)\n", res.Ahead, res.Behind)
+		if len(res.Actions) > 0 {
+			b.WriteString("\n")
+			for _, action := range res.Actions {
+				fmt.Fprintf(&b, "- %s\n", action)
+			}
+		}
+		if repo.Err != "" {
+			fmt.Fprintf(&b, "\n> Branch analysis failed: %s\n", repo.Err)
+		}
+		if len(repo.Branches) == 0 {
+			continue
+		}
+
+		b.WriteString("\n| " + strings.Join(tui.BranchTableHeader, " | ") + " |\n")
+		b.WriteString("|" + strings.Repeat(" --- |", len(tui.BranchTableHeader)) + "\n")
+		for _, row := range repo.Branches {
+			cells := tui.BranchTableCells(row)
+			for i := range cells {
+				cells[i] = cell(cells[i])
+			}
+			cells[2] = statusCell(row.Status)
+			b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+		}
+	}
+	return b.String()
+}
+
+func needsAttention(r service.Report) int {
+	count := 0
+	for _, repo := range r.Repositories {
+		if repo.Result.Status != domain.StatusSynced || len(repo.Result.Flags) > 0 {
+			count++
+		}
+	}
+	return count
+}
+
+func statusCell(status domain.Status) string {
+	if icon, ok := severityIcons[status.Severity()]; ok {
+		return icon + " " + string(status)
+	}
+	return string(status)
+}
+
+func cell(value string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(value, "|", `\|`), "\n", " ")
+}
+
+func joinFlags(flags []string) string {
+	if len(flags) == 0 {
+		return "-"
+	}
+	return strings.Join(flags, ", ")
+}
+
+func fallback(value string, alt string) string {
+	if strings.TrimSpace(value) == "" {
+		return alt
+	}
+	return value
+}
@@ -0,0 +1,74 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+	"github.com/guionardo/git_sync_status/internal/service"
+)
+
+func sampleReport() service.Report {
+	return service.Report{
+		GeneratedAt: time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC),
+		Repositories: []service.RepositoryReport{
+			{
+				Result: domain.Result{
+					RepoPath: "/src/api", Branch: "main", Upstream: "origin/main", Status: domain.StatusDiverged,
+					Ahead: 2, Behind: 1, Flags: []string{"WORKTREE_DIRTY"}, Actions: []string{"Review incoming changes: git pull --rebase"},
+				},
+				Branches: []service.BranchStatus{
+					{Branch: "main", Upstream: "origin/main", Status: domain.StatusDiverged, Ahead: 2, Behind: 1},
+					{Branch: "fix|pipe", Status: domain.StatusNoUpstream, LastCommitAuthor: "<script>alert(1)</script>"},
+				},
+			},
+			{Result: domain.Result{RepoPath: "/src/docs", Branch: "main", Status: domain.StatusSynced}},
+		},
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	t.Parallel()
+
+	out := Markdown(sampleReport())
+	for _, want := range []string{
+		"Generated 2024-05-01 09:30 UTC · 2 repositories · 1 need attention",
+		"| /src/api | main | ❌ DIVERGED | 2/1 | WORKTREE_DIRTY |",
+		"| /src/docs | main | ✅ SYNCED | 0/0 | - |",
+		"## /src/api",
+		"❌ DIVERGED `main` → `origin/main` (ahead 2, behind 1)",
+		"- Review incoming changes: git pull --rebase",
+		"| BRANCH | UPSTREAM | STATUS | A/B | VS DEFAULT | LAST COMMIT | CREATED | AUTHOR | FLAGS |",
+		`| fix\|pipe | - | ⚠️ NO_UPSTREAM | 0/0 |`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("markdown missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestHTML(t *testing.T) {
+	t.Parallel()
+
+	var b strings.Builder
+	if err := HTML(&b, sampleReport()); err != nil {
+		t.Fatalf("HTML() error = %v", err)
+	}
+	out := b.String()
+	for _, want := range []string{
+		"<!DOCTYPE html>",
+		`<table class="sortable">`,
+		`<td class="status error">DIVERGED</td>`,
+		`<td class="status ok">SYNCED</td>`,
+		"<th>VS DEFAULT</th>",
+		"&lt;script&gt;alert(1)&lt;/script&gt;",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("html missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "<script>alert(1)") {
+		t.Fatal("html output is not escaped")
+	}
+}
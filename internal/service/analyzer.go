@@ -4,24 +4,112 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/guionardo/git_sync_status/internal/domain"
 	"github.com/guionardo/git_sync_status/internal/gitclient"
+	"github.com/guionardo/git_sync_status/internal/lfs"
 )
 
+const (
+	DefaultStaleAfter  = 90 * 24 * time.Hour
+	DefaultMaxFileSize = 5 << 20
+
+	StaleCheckDisabled time.Duration = -1
+)
+
+type network int
+
+const (
+	offline network = iota
+	online
+	prefetched
+)
+
+const (
+	worktreeDirtyDetail      = "Working tree has staged, unstaged, or untracked changes"
+	reviewLocalChangesAction = "Review local changes: git status"
+)
+
+type Options struct {
+	StaleAfter  time.Duration
+	MaxFileSize int64
+}
+
 type Analyzer struct {
-	client gitclient.Client
-	remote string
+	client  gitclient.Client
+	lfs     LFSRemote
+	remote  string
+	options Options
+	cache   ResultCache
+	history HistoryRecorder
+	now     func() time.Time
 }
 
 func NewAnalyzer(client gitclient.Client, remote string) *Analyzer {
 	if remote == "" {
 		remote = "origin"
 	}
-	return &Analyzer{client: client, remote: remote}
+	return &Analyzer{
+		client:  client,
+		lfs:     lfs.NewBatchClient(nil),
+		remote:  remote,
+		options: Options{StaleAfter: DefaultStaleAfter, MaxFileSize: DefaultMaxFileSize},
+		now:     time.Now,
+	}
+}
+
+func (a *Analyzer) WithOptions(opts Options) *Analyzer {
+	if opts.StaleAfter == 0 {
+		opts.StaleAfter = DefaultStaleAfter
+	}
+	if opts.MaxFileSize <= 0 {
+		opts.MaxFileSize = DefaultMaxFileSize
+	}
+	a.options = opts
+	return a
 }
 
 func (a *Analyzer) Analyze(ctx context.Context, repoPath string) domain.Result {
+	mode := online
+	if a.cache != nil && a.client.FetchPrune(ctx, repoPath, a.remote) == nil {
+		if result, ok := a.cache.Get(repoPath, a.cacheVariant()); ok {
+			a.refreshWorktreeState(ctx, repoPath, &result)
+			a.recordHistory(result)
+			return result
+		}
+		mode = prefetched
+	}
+	result := a.analyze(ctx, repoPath, mode)
+	if a.cache != nil && cacheable(result) {
+		_ = a.cache.Put(repoPath, a.cacheVariant(), result)
+	}
+	a.recordHistory(result)
+	return result
+}
+
+func (a *Analyzer) AnalyzeLocal(ctx context.Context, repoPath string) domain.Result {
+	result := a.analyzeRepo(ctx, repoPath, offline)
+	if result.Status != domain.StatusNotAGitRepo {
+		a.enrichSubmodules(ctx, repoPath, &result)
+	}
+	return result
+}
+
+func (a *Analyzer) analyze(ctx context.Context, repoPath string, mode network) domain.Result {
+	result := a.analyzeRepo(ctx, repoPath, mode)
+	if result.Status != domain.StatusNotAGitRepo {
+		a.enrichSubmodules(ctx, repoPath, &result)
+		a.enrichWorktrees(ctx, repoPath, &result)
+	}
+	if result.Status != domain.StatusNotAGitRepo && result.Status != domain.StatusNoRemote {
+		a.enrichLFS(ctx, repoPath, &result)
+		a.enrichTags(ctx, repoPath, &result)
+	}
+	return result
+}
+
+func (a *Analyzer) analyzeRepo(ctx context.Context, repoPath string, mode network) domain.Result {
 	result := domain.Result{RepoPath: repoPath}
 
 	isRepo, err := a.client.IsGitRepo(ctx, repoPath)
@@ -60,14 +148,32 @@ func (a *Analyzer) Analyze(ctx context.Context, repoPath string) domain.Result {
 		result.Actions = []string{
 			fmt.Sprintf("Add remote: git remote add %s <url>", a.remote),
 		}
+		if detached {
+			a.enrichDetachedHead(ctx, repoPath, &result, offline)
+		}
 		a.enrichWorktreeState(ctx, repoPath, &result)
 		return result
 	}
 
-	reachable, _ := a.client.RemoteReachable(ctx, repoPath, a.remote)
-	if !reachable {
-		result.Flags = append(result.Flags, "REMOTE_UNREACHABLE")
-		result.Details = append(result.Details, fmt.Sprintf("Remote %q is unreachable", a.remote))
+	if mode != offline {
+		reachable, _ := a.client.RemoteReachable(ctx, repoPath, a.remote)
+		if !reachable {
+			result.Flags = append(result.Flags, "REMOTE_UNREACHABLE")
+			result.Details = append(result.Details, fmt.Sprintf("Remote %q is unreachable", a.remote))
+		}
+	}
+
+	if detached {
+		if mode == online {
+			if err := a.client.FetchPrune(ctx, repoPath, a.remote); err != nil && !result.HasFlag("REMOTE_UNREACHABLE") {
+				result.Flags = append(result.Flags, "REMOTE_UNREACHABLE")
+				result.Details = append(result.Details, "Fetch failed; remote branches may be stale")
+			}
+		}
+		result.Status = domain.StatusDetached
+		a.enrichDetachedHead(ctx, repoPath, &result, mode)
+		a.enrichWorktreeState(ctx, repoPath, &result)
+		return result
 	}
 
 	upstream, err := a.client.Upstream(ctx, repoPath)
@@ -77,6 +183,7 @@ func (a *Analyzer) Analyze(ctx context.Context, repoPath string) domain.Result {
 			fmt.Sprintf("Set upstream: git push -u %s %s", a.remote, fallbackBranch(result.Branch)),
 		}
 		a.enrichNoUpstreamHints(ctx, repoPath, &result)
+		a.enrichOutgoingRisks(ctx, repoPath, &result)
 		a.enrichWorktreeState(ctx, repoPath, &result)
 		return result
 	}
@@ -91,9 +198,11 @@ func (a *Analyzer) Analyze(ctx context.Context, repoPath string) domain.Result {
 	}
 	result.Upstream = upstream
 
-	if err := a.client.FetchPrune(ctx, repoPath, a.remote); err != nil {
-		result.Flags = append(result.Flags, "REMOTE_UNREACHABLE")
-		result.Details = append(result.Details, "Fetch failed; ahead/behind may be stale")
+	if mode == online {
+		if err := a.client.FetchPrune(ctx, repoPath, a.remote); err != nil {
+			result.Flags = append(result.Flags, "REMOTE_UNREACHABLE")
+			result.Details = append(result.Details, "Fetch failed; ahead/behind may be stale")
+		}
 	}
 
 	behind, ahead, err := a.client.AheadBehind(ctx, repoPath)
@@ -123,9 +232,17 @@ func (a *Analyzer) Analyze(ctx context.Context, repoPath string) domain.Result {
 		result.Actions = []string{"No sync action required"}
 	}
 
+	if result.Status == domain.StatusLate || result.Status == domain.StatusDiverged {
+		a.enrichConflictPrediction(ctx, repoPath, &result)
+	}
+	if result.Ahead > 0 {
+		a.enrichCommitPolicy(ctx, repoPath, &result)
+		a.enrichOutgoingRisks(ctx, repoPath, &result)
+	}
+
 	a.enrichWorktreeState(ctx, repoPath, &result)
 	if result.HasFlag("WORKTREE_DIRTY") {
-		result.Actions = append(result.Actions, "Review local changes: git status")
+		result.Actions = append(result.Actions, reviewLocalChangesAction)
 	}
 
 	return result
@@ -159,7 +276,7 @@ func (a *Analyzer) enrichWorktreeState(ctx context.Context, repoPath string, res
 	}
 	if dirty {
 		result.Flags = append(result.Flags, "WORKTREE_DIRTY")
-		result.Details = append(result.Details, "Working tree has staged, unstaged, or untracked changes")
+		result.Details = append(result.Details, worktreeDirtyDetail)
 	}
 }
 
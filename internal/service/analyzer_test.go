@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/gitclient"
 )
 
 type fakeClient struct {
@@ -24,6 +27,29 @@ type fakeClient struct {
 	merged           bool
 	mergedErr        error
 	branches         []string
+	remoteBranches   []gitclient.RefInfo
+	ancestors        map[string]bool
+	commitInfos      map[string]gitclient.RefInfo
+	createdAt        time.Time
+	conflicts        map[string][]string
+	submodules       map[string][]gitclient.Submodule
+	topLevel         string
+	worktrees        []gitclient.Worktree
+	lfsPointers      []gitclient.LFSPointer
+	credential       gitclient.Credential
+	lfsEndpoint      string
+	lfsHeader        map[string]string
+	localTags        map[string]string
+	remoteTags       map[string]string
+	reflogs          map[string][]string
+	commitsNotIn     map[string][]gitclient.Commit
+	refsContaining   []string
+	tagsPointingAt   []string
+	config           map[string]string
+	signatures       []gitclient.CommitSignature
+	outgoingFiles    []gitclient.OutgoingFile
+	outgoingPatches  []gitclient.FilePatch
+	hooksDir         string
 }
 
 func (f *fakeClient) IsGitRepo(context.Context, string) (bool, error) { return f.isRepo, nil }
@@ -58,6 +84,88 @@ func (f *fakeClient) IsBranchMergedInto(context.Context, string, string, string)
 	return f.merged, f.mergedErr
 }
 func (f *fakeClient) LocalBranches(context.Context, string) ([]string, error) { return f.branches, nil }
+func (f *fakeClient) RemoteBranches(context.Context, string, string) ([]gitclient.RefInfo, error) {
+	return f.remoteBranches, nil
+}
+func (f *fakeClient) IsAncestor(_ context.Context, _ string, ancestor string, _ string) (bool, error) {
+	return f.ancestors[ancestor], nil
+}
+
+func (f *fakeClient) CommitInfo(_ context.Context, _ string, ref string) (gitclient.RefInfo, error) {
+	info, ok := f.commitInfos[ref]
+	if !ok {
+		return gitclient.RefInfo{}, errors.New("unknown ref")
+	}
+	return info, nil
+}
+func (f *fakeClient) BranchCreatedAt(context.Context, string, string) (time.Time, bool, error) {
+	return f.createdAt, !f.createdAt.IsZero(), nil
+}
+
+func (f *fakeClient) PredictMergeConflicts(_ context.Context, _ string, _ string, theirs string) ([]string, error) {
+	return f.conflicts[theirs], nil
+}
+
+func (f *fakeClient) Submodules(_ context.Context, path string) ([]gitclient.Submodule, error) {
+	return f.submodules[path], nil
+}
+
+func (f *fakeClient) TopLevel(context.Context, string) (string, error) { return f.topLevel, nil }
+func (f *fakeClient) Worktrees(context.Context, string) ([]gitclient.Worktree, error) {
+	return f.worktrees, nil
+}
+
+func (f *fakeClient) LFSPointers(context.Context, string) ([]gitclient.LFSPointer, error) {
+	return f.lfsPointers, nil
+}
+func (f *fakeClient) CredentialFill(context.Context, string, string) (gitclient.Credential, error) {
+	return f.credential, nil
+}
+
+func (f *fakeClient) LFSEndpoint(context.Context, string, string) (gitclient.LFSEndpoint, error) {
+	return gitclient.LFSEndpoint{URL: f.lfsEndpoint, Header: f.lfsHeader}, nil
+}
+
+func (f *fakeClient) LocalTags(context.Context, string) (map[string]string, error) {
+	return f.localTags, nil
+}
+func (f *fakeClient) RemoteTags(context.Context, string, string) (map[string]string, error) {
+	return f.remoteTags, nil
+}
+
+func (f *fakeClient) ReflogCommits(_ context.Context, _ string, ref string) ([]string, error) {
+	return f.reflogs[ref], nil
+}
+func (f *fakeClient) CommitsNotIn(_ context.Context, _ string, tips []string, _ []string) ([]gitclient.Commit, error) {
+	var out []gitclient.Commit
+	for _, tip := range tips {
+		out = append(out, f.commitsNotIn[tip]...)
+	}
+	return out, nil
+}
+
+func (f *fakeClient) RefsContaining(context.Context, string, string) ([]string, error) {
+	return f.refsContaining, nil
+}
+func (f *fakeClient) TagsPointingAt(context.Context, string, string) ([]string, error) {
+	return f.tagsPointingAt, nil
+}
+
+func (f *fakeClient) ConfigValue(_ context.Context, _ string, key string) (string, error) {
+	return f.config[key], nil
+}
+func (f *fakeClient) CommitSignatures(context.Context, string, string) ([]gitclient.CommitSignature, error) {
+	return f.signatures, nil
+}
+
+func (f *fakeClient) OutgoingFiles(context.Context, string, []string) ([]gitclient.OutgoingFile, error) {
+	return f.outgoingFiles, nil
+}
+func (f *fakeClient) OutgoingPatches(context.Context, string, []string) ([]gitclient.FilePatch, error) {
+	return f.outgoingPatches, nil
+}
+
+func (f *fakeClient) HooksDir(context.Context, string) (string, error) { return f.hooksDir, nil }
 
 func TestAnalyzerStatuses(t *testing.T) {
 	t.Parallel()
@@ -146,3 +254,32 @@ func TestAnalyzerAddsDirtyFlag(t *testing.T) {
 		t.Fatalf("expected WORKTREE_DIRTY flag")
 	}
 }
+
+func TestAnalyzerPredictsConflicts(t *testing.T) {
+	t.Parallel()
+	fc := &fakeClient{
+		isRepo: true, currentBranch: "feature", hasRemote: true, reachable: true,
+		upstream: "origin/feature", behind: 2, ahead: 1, defaultBranch: "main",
+		conflicts: map[string][]string{"origin/main": {"go.mod", "main.go"}},
+	}
+	got := NewAnalyzer(fc, "origin").Analyze(context.Background(), "/tmp/repo")
+	if !got.HasFlag("WILL_CONFLICT") {
+		t.Fatalf("expected WILL_CONFLICT flag, got %v", got.Flags)
+	}
+	if len(got.Conflicts) != 1 || got.Conflicts[0].Against != "origin/main" || len(got.Conflicts[0].Files) != 2 {
+		t.Fatalf("unexpected conflicts: %+v", got.Conflicts)
+	}
+}
+
+func TestAnalyzerSkipsConflictPredictionWhenSynced(t *testing.T) {
+	t.Parallel()
+	fc := &fakeClient{
+		isRepo: true, currentBranch: "main", hasRemote: true, reachable: true,
+		upstream: "origin/main", defaultBranch: "main",
+		conflicts: map[string][]string{"origin/main": {"main.go"}},
+	}
+	got := NewAnalyzer(fc, "origin").Analyze(context.Background(), "/tmp/repo")
+	if got.HasFlag("WILL_CONFLICT") {
+		t.Fatalf("did not expect WILL_CONFLICT flag for synced branch")
+	}
+}
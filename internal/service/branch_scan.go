@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/guionardo/git_sync_status/internal/domain"
 )
@@ -11,12 +13,25 @@ type BranchSummary struct {
 }
 
 type BranchStatus struct {
-	Branch   string
-	Upstream string
-	Status   domain.Status
-	Behind   int
-	Ahead    int
-	Flags    []string
+	RepoPath         string
+	Branch           string
+	Upstream         string
+	Status           domain.Status
+	Behind           int
+	Ahead            int
+	Flags            []string
+	LastCommitDate   time.Time
+	LastCommitAuthor string
+	CreatedAt        time.Time
+	CreatedApprox    bool
+	Stale            bool
+	DefaultBranch    string
+	DefaultBehind    int
+	DefaultAhead     int
+	Suggestion       string
+	Conflicts        []domain.ConflictPrediction
+	Worktree         string
+	Details          []string
 }
 
 func (a *Analyzer) ScanLocalBranches(ctx context.Context, repoPath string) ([]BranchSummary, error) {
@@ -38,12 +53,22 @@ func (a *Analyzer) AnalyzeAllBranches(ctx context.Context, repoPath string) ([]B
 		return nil, err
 	}
 
+	base := a.defaultRemoteBranch(ctx, repoPath)
+	elsewhere := a.branchesInOtherWorktrees(ctx, repoPath)
+
 	rows := make([]BranchStatus, 0, len(branches))
 	for _, branch := range branches {
 		row := BranchStatus{
-			Branch: branch,
-			Status: domain.StatusNoUpstream,
+			RepoPath: repoPath,
+			Branch:   branch,
+			Status:   domain.StatusNoUpstream,
+		}
+		if path, ok := elsewhere[branch]; ok {
+			row.Worktree = path
+			row.Flags = append(row.Flags, "CHECKED_OUT_ELSEWHERE")
 		}
+		a.enrichBranchAge(ctx, repoPath, base, &row)
+		a.enrichDefaultBranchComparison(ctx, repoPath, base, &row)
 
 		upstream, err := a.client.UpstreamForBranch(ctx, repoPath, branch)
 		if err != nil {
@@ -71,9 +96,73 @@ func (a *Analyzer) AnalyzeAllBranches(ctx context.Context, repoPath string) ([]B
 		default:
 			row.Status = domain.StatusSynced
 		}
+		if row.Status == domain.StatusLate || row.Status == domain.StatusDiverged {
+			a.predictBranchConflicts(ctx, repoPath, base, &row)
+		}
 
 		rows = append(rows, row)
 	}
 
 	return rows, nil
 }
+
+func (a *Analyzer) enrichBranchAge(ctx context.Context, repoPath string, base string, row *BranchStatus) {
+	info, err := a.client.CommitInfo(ctx, repoPath, "refs/heads/"+row.Branch)
+	if err != nil {
+		return
+	}
+	row.LastCommitDate = info.CommitDate
+	row.LastCommitAuthor = info.AuthorName
+
+	created, recorded, err := a.client.BranchCreatedAt(ctx, repoPath, row.Branch)
+	if err == nil {
+		row.CreatedAt = created
+	}
+	if !recorded {
+		a.estimateBranchCreation(ctx, repoPath, base, row)
+	}
+
+	lastActivity := row.LastCommitDate
+	if row.CreatedAt.After(lastActivity) {
+		lastActivity = row.CreatedAt
+	}
+	if a.options.StaleAfter > 0 && !lastActivity.IsZero() && a.now().Sub(lastActivity) > a.options.StaleAfter {
+		row.Stale = true
+		row.Flags = append(row.Flags, "STALE")
+	}
+}
+
+func (a *Analyzer) estimateBranchCreation(ctx context.Context, repoPath string, base string, row *BranchStatus) {
+	source := "the oldest reflog entry"
+	if base != "" {
+		commits, err := a.client.CommitsNotIn(ctx, repoPath, []string{"refs/heads/" + row.Branch}, []string{base})
+		if err == nil && len(commits) > 0 {
+			if first := commits[len(commits)-1].Date; row.CreatedAt.IsZero() || first.Before(row.CreatedAt) {
+				row.CreatedAt = first
+				source = "the first commit not on " + base
+			}
+		}
+	}
+	if row.CreatedAt.IsZero() {
+		return
+	}
+	row.CreatedApprox = true
+	row.Details = append(row.Details, fmt.Sprintf("Creation date is approximate: the reflog does not record when %s was created, so %s was used", row.Branch, source))
+}
+
+func (a *Analyzer) enrichDefaultBranchComparison(ctx context.Context, repoPath string, base string, row *BranchStatus) {
+	if base == "" {
+		return
+	}
+	behind, ahead, err := a.client.AheadBehindRefs(ctx, repoPath, base, row.Branch)
+	if err != nil {
+		return
+	}
+	row.DefaultBranch = base
+	row.DefaultBehind = behind
+	row.DefaultAhead = ahead
+
+	if behind > 0 && ahead > 0 {
+		row.Suggestion = fmt.Sprintf("%s is %d commits behind %s; rebase before opening a PR: git rebase %s", row.Branch, behind, base, base)
+	}
+}
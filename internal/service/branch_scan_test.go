@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/gitclient"
+)
+
+func TestAnalyzeAllBranchesStaleness(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	fc := &fakeClient{
+		branches: []string{"fresh", "abandoned"},
+		upstream: "origin/x",
+		commitInfos: map[string]gitclient.RefInfo{
+			"refs/heads/fresh":     {AuthorName: "alice", CommitDate: now.Add(-24 * time.Hour)},
+			"refs/heads/abandoned": {AuthorName: "bob", CommitDate: now.Add(-40 * 24 * time.Hour)},
+		},
+		createdAt: now.Add(-60 * 24 * time.Hour),
+	}
+
+	analyzer := NewAnalyzer(fc, "origin").WithOptions(Options{StaleAfter: 30 * 24 * time.Hour})
+	analyzer.now = func() time.Time { return now }
+
+	rows, err := analyzer.AnalyzeAllBranches(context.Background(), "/tmp/repo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+	if rows[0].Stale || rows[0].LastCommitAuthor != "alice" {
+		t.Fatalf("unexpected fresh row: %+v", rows[0])
+	}
+	if !rows[1].Stale || !hasFlag(rows[1].Flags, "STALE") || !rows[1].CreatedAt.Equal(fc.createdAt) {
+		t.Fatalf("unexpected abandoned row: %+v", rows[1])
+	}
+}
+
+func TestAnalyzeAllBranchesStalenessUsesCreationAndCanBeDisabled(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	fc := &fakeClient{
+		branches: []string{"revived"},
+		upstream: "origin/x",
+		commitInfos: map[string]gitclient.RefInfo{
+			"refs/heads/revived": {AuthorName: "alice", CommitDate: now.Add(-400 * 24 * time.Hour)},
+		},
+		createdAt: now.Add(-time.Hour),
+	}
+
+	analyzer := NewAnalyzer(fc, "origin").WithOptions(Options{StaleAfter: 30 * 24 * time.Hour})
+	analyzer.now = func() time.Time { return now }
+	rows, err := analyzer.AnalyzeAllBranches(context.Background(), "/tmp/repo")
+	if err != nil || len(rows) != 1 || rows[0].Stale {
+		t.Fatalf("branch created an hour ago from an old commit: rows = %+v, err = %v", rows, err)
+	}
+
+	fc.createdAt = now.Add(-400 * 24 * time.Hour)
+	disabled := NewAnalyzer(fc, "origin").WithOptions(Options{StaleAfter: StaleCheckDisabled})
+	disabled.now = func() time.Time { return now }
+	rows, err = disabled.AnalyzeAllBranches(context.Background(), "/tmp/repo")
+	if err != nil || len(rows) != 1 || rows[0].Stale {
+		t.Fatalf("stale check disabled: rows = %+v, err = %v", rows, err)
+	}
+}
+
+func hasFlag(flags []string, flag string) bool {
+	for _, f := range flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+func TestAnalyzeAllBranchesDefaultBranchComparison(t *testing.T) {
+	t.Parallel()
+
+	fc := &fakeClient{
+		branches:      []string{"feature/x"},
+		upstream:      "origin/feature/x",
+		defaultBranch: "main",
+		behind:        40,
+		ahead:         2,
+	}
+
+	rows, err := NewAnalyzer(fc, "origin").AnalyzeAllBranches(context.Background(), "/tmp/repo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	row := rows[0]
+	if row.DefaultBranch != "origin/main" || row.DefaultBehind != 40 || row.DefaultAhead != 2 {
+		t.Fatalf("unexpected default comparison: %+v", row)
+	}
+	if row.Suggestion == "" {
+		t.Fatalf("expected rebase suggestion")
+	}
+}
+
+func TestAnalyzeAllBranchesEstimatesCreationWithoutReflog(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	fc := &fakeClient{
+		branches:      []string{"old"},
+		upstream:      "origin/old",
+		defaultBranch: "main",
+		commitInfos: map[string]gitclient.RefInfo{
+			"refs/heads/old": {AuthorName: "alice", CommitDate: now.Add(-24 * time.Hour)},
+		},
+		commitsNotIn: map[string][]gitclient.Commit{
+			"refs/heads/old": {{SHA: "c2", Date: now.Add(-24 * time.Hour)}, {SHA: "c1", Date: now.Add(-10 * 24 * time.Hour)}},
+		},
+	}
+
+	rows, err := NewAnalyzer(fc, "origin").AnalyzeAllBranches(context.Background(), "/tmp/repo")
+	if err != nil || len(rows) != 1 {
+		t.Fatalf("rows = %+v, err = %v", rows, err)
+	}
+	row := rows[0]
+	if !row.CreatedAt.Equal(now.Add(-10*24*time.Hour)) || !row.CreatedApprox {
+		t.Fatalf("created = %s approx = %v, want first commit not on origin/main", row.CreatedAt, row.CreatedApprox)
+	}
+	if len(row.Details) != 1 || !strings.Contains(row.Details[0], "approximate") || !strings.Contains(row.Details[0], "origin/main") {
+		t.Fatalf("details = %q", row.Details)
+	}
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+)
+
+const (
+	ConfigRequireSignedCommits  = "sync-status.requireSignedCommits"
+	ConfigAuthorEmailPattern    = "sync-status.authorEmailPattern"
+	ConfigCommitterEmailPattern = "sync-status.committerEmailPattern"
+)
+
+type CommitPolicy struct {
+	RequireSigned  bool
+	AuthorEmail    *regexp.Regexp
+	CommitterEmail *regexp.Regexp
+}
+
+func (p CommitPolicy) Enabled() bool {
+	return p.RequireSigned || p.AuthorEmail != nil || p.CommitterEmail != nil
+}
+
+func (a *Analyzer) LoadCommitPolicy(ctx context.Context, repoPath string) (CommitPolicy, error) {
+	var policy CommitPolicy
+
+	signed, err := a.client.ConfigValue(ctx, repoPath, ConfigRequireSignedCommits)
+	if err != nil {
+		return policy, err
+	}
+	policy.RequireSigned = gitConfigBool(signed)
+
+	for key, target := range map[string]**regexp.Regexp{
+		ConfigAuthorEmailPattern:    &policy.AuthorEmail,
+		ConfigCommitterEmailPattern: &policy.CommitterEmail,
+	} {
+		value, err := a.client.ConfigValue(ctx, repoPath, key)
+		if err != nil {
+			return policy, err
+		}
+		if value == "" {
+			continue
+		}
+		re, err := regexp.Compile(value)
+		if err != nil {
+			return policy, fmt.Errorf("invalid %s %q: %w", key, value, err)
+		}
+		*target = re
+	}
+	return policy, nil
+}
+
+func (a *Analyzer) enrichCommitPolicy(ctx context.Context, repoPath string, result *domain.Result) {
+	policy, err := a.LoadCommitPolicy(ctx, repoPath)
+	if err != nil {
+		result.Details = append(result.Details, fmt.Sprintf("Could not load commit policy: %v", err))
+		return
+	}
+	if !policy.Enabled() {
+		return
+	}
+
+	sigs, err := a.client.CommitSignatures(ctx, repoPath, result.Upstream+"..HEAD")
+	if err != nil {
+		result.Details = append(result.Details, fmt.Sprintf("Could not inspect outgoing commits: %v", err))
+		return
+	}
+
+	status := &domain.PolicyStatus{CheckedCommits: len(sigs)}
+	for _, sig := range sigs {
+		if policy.RequireSigned && !validSignature(sig.Status) {
+			status.UnsignedCommits = append(status.UnsignedCommits, sig.SHA)
+		}
+		if (policy.AuthorEmail != nil && !policy.AuthorEmail.MatchString(sig.AuthorEmail)) ||
+			(policy.CommitterEmail != nil && !policy.CommitterEmail.MatchString(sig.CommitterEmail)) {
+			status.ForeignAuthorCommits = append(status.ForeignAuthorCommits, sig.SHA)
+		}
+	}
+	result.Policy = status
+
+	if len(status.UnsignedCommits) > 0 {
+		result.Flags = append(result.Flags, "UNSIGNED_COMMITS")
+		result.Details = append(result.Details, fmt.Sprintf("%d outgoing commit(s) lack a valid signature", len(status.UnsignedCommits)))
+		result.Actions = append(result.Actions, fmt.Sprintf("Re-sign outgoing commits: git rebase --exec 'git commit --amend --no-edit -S' %s", result.Upstream))
+	}
+	if len(status.ForeignAuthorCommits) > 0 {
+		result.Flags = append(result.Flags, "FOREIGN_AUTHOR")
+		result.Details = append(result.Details, fmt.Sprintf("%d outgoing commit(s) have an author or committer email outside the policy", len(status.ForeignAuthorCommits)))
+		result.Actions = append(result.Actions, fmt.Sprintf("Fix authorship: git rebase --exec 'git commit --amend --no-edit --reset-author' %s", result.Upstream))
+	}
+}
+
+func validSignature(code string) bool {
+	return code == "G" || code == "U"
+}
+
+func gitConfigBool(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "true", "yes", "on", "1":
+		return true
+	default:
+		return false
+	}
+}
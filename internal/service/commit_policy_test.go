@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/guionardo/git_sync_status/internal/gitclient"
+)
+
+func TestAnalyzerCommitPolicy(t *testing.T) {
+	t.Parallel()
+
+	fc := &fakeClient{
+		isRepo: true, currentBranch: "main", hasRemote: true, reachable: true,
+		upstream: "origin/main", ahead: 3,
+		config: map[string]string{
+			ConfigRequireSignedCommits: "yes",
+			ConfigAuthorEmailPattern:   `@corp\.example$`,
+		},
+		signatures: []gitclient.CommitSignature{
+			{SHA: "good", Status: "G", AuthorEmail: "dev@corp.example"},
+			{SHA: "unsigned", Status: "N", AuthorEmail: "dev@corp.example"},
+			{SHA: "foreign", Status: "U", AuthorEmail: "dev@gmail.example"},
+		},
+	}
+
+	got := NewAnalyzer(fc, "origin").Analyze(context.Background(), "/tmp/repo")
+	if !got.HasFlag("UNSIGNED_COMMITS") || !got.HasFlag("FOREIGN_AUTHOR") {
+		t.Fatalf("expected policy flags, got %v", got.Flags)
+	}
+	if got.Policy == nil || got.Policy.CheckedCommits != 3 {
+		t.Fatalf("unexpected policy status: %+v", got.Policy)
+	}
+	if len(got.Policy.UnsignedCommits) != 1 || got.Policy.UnsignedCommits[0] != "unsigned" {
+		t.Fatalf("unexpected unsigned commits: %v", got.Policy.UnsignedCommits)
+	}
+	if len(got.Policy.ForeignAuthorCommits) != 1 || got.Policy.ForeignAuthorCommits[0] != "foreign" {
+		t.Fatalf("unexpected foreign commits: %v", got.Policy.ForeignAuthorCommits)
+	}
+}
+
+func TestAnalyzerCommitPolicyDisabledByDefault(t *testing.T) {
+	t.Parallel()
+
+	fc := &fakeClient{
+		isRepo: true, currentBranch: "main", hasRemote: true, reachable: true,
+		upstream: "origin/main", ahead: 1,
+		signatures: []gitclient.CommitSignature{{SHA: "unsigned", Status: "N"}},
+	}
+
+	got := NewAnalyzer(fc, "origin").Analyze(context.Background(), "/tmp/repo")
+	if got.Policy != nil || got.HasFlag("UNSIGNED_COMMITS") {
+		t.Fatalf("policy must be opt-in, got %+v flags %v", got.Policy, got.Flags)
+	}
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+)
+
+func (a *Analyzer) enrichConflictPrediction(ctx context.Context, repoPath string, result *domain.Result) {
+	base := a.defaultRemoteBranch(ctx, repoPath)
+	conflicts, failures := a.predictConflicts(ctx, repoPath, "HEAD", result.Upstream, base)
+	result.Details = append(result.Details, failures...)
+	if len(conflicts) == 0 {
+		return
+	}
+
+	result.Conflicts = conflicts
+	result.Flags = append(result.Flags, "WILL_CONFLICT")
+	for _, c := range conflicts {
+		operation := "git pull --rebase"
+		if c.Against != result.Upstream {
+			operation = "git merge " + c.Against
+		}
+		result.Actions = append(result.Actions, fmt.Sprintf("Expect conflicts in %s when running %s", strings.Join(c.Files, ", "), operation))
+	}
+}
+
+func (a *Analyzer) predictBranchConflicts(ctx context.Context, repoPath string, base string, row *BranchStatus) {
+	conflicts, _ := a.predictConflicts(ctx, repoPath, "refs/heads/"+row.Branch, row.Upstream, base)
+	if len(conflicts) == 0 {
+		return
+	}
+	row.Conflicts = conflicts
+	row.Flags = append(row.Flags, "WILL_CONFLICT")
+}
+
+func (a *Analyzer) predictConflicts(ctx context.Context, repoPath string, ours string, upstream string, base string) ([]domain.ConflictPrediction, []string) {
+	targets := []string{upstream}
+	if base != "" && base != upstream {
+		targets = append(targets, base)
+	}
+
+	var conflicts []domain.ConflictPrediction
+	var failures []string
+	for _, target := range targets {
+		files, err := a.client.PredictMergeConflicts(ctx, repoPath, ours, target)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("Conflict prediction against %s failed: %v", target, err))
+			continue
+		}
+		if len(files) > 0 {
+			conflicts = append(conflicts, domain.ConflictPrediction{Against: target, Files: files})
+		}
+	}
+	return conflicts, failures
+}
+
+func (a *Analyzer) defaultRemoteBranch(ctx context.Context, repoPath string) string {
+	defaultBranch, err := a.client.DefaultBranch(ctx, repoPath, a.remote)
+	if err != nil || defaultBranch == "" {
+		return ""
+	}
+	return a.remote + "/" + defaultBranch
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+)
+
+func (a *Analyzer) enrichDetachedHead(ctx context.Context, repoPath string, result *domain.Result, mode network) {
+	info, err := a.client.CommitInfo(ctx, repoPath, "HEAD")
+	if err != nil {
+		result.Details = append(result.Details, fmt.Sprintf("Could not resolve detached HEAD: %v", err))
+		return
+	}
+	refs, err := a.client.RefsContaining(ctx, repoPath, info.Commit)
+	if err != nil {
+		result.Details = append(result.Details, fmt.Sprintf("Could not find refs containing HEAD: %v", err))
+		return
+	}
+
+	status := &domain.DetachedHeadStatus{Commit: info.Commit}
+	for _, ref := range refs {
+		switch {
+		case strings.HasPrefix(ref, "refs/heads/"):
+			status.LocalBranches = append(status.LocalBranches, strings.TrimPrefix(ref, "refs/heads/"))
+		case strings.HasPrefix(ref, "refs/remotes/"):
+			status.RemoteBranches = append(status.RemoteBranches, strings.TrimPrefix(ref, "refs/remotes/"))
+			status.OnRemote = true
+		case strings.HasPrefix(ref, "refs/tags/"):
+			status.Tags = append(status.Tags, strings.TrimPrefix(ref, "refs/tags/"))
+		}
+	}
+	var remoteTags []string
+	if !status.OnRemote && mode != offline && !result.HasFlag("REMOTE_UNREACHABLE") {
+		remoteTags = a.remoteTagsAt(ctx, repoPath, info.Commit)
+		status.OnRemote = len(remoteTags) > 0
+	}
+	result.Detached = status
+
+	short := shortSHA(info.Commit)
+	switch {
+	case len(status.RemoteBranches) > 0:
+		result.Details = append(result.Details, fmt.Sprintf("Detached commit %s is contained in %s", short, strings.Join(status.RemoteBranches, ", ")))
+		result.Actions = append(result.Actions, fmt.Sprintf("Commit is backed up remotely; return to a branch when done: git switch %s", detachedSwitchTarget(status)))
+	case len(remoteTags) > 0:
+		result.Details = append(result.Details, fmt.Sprintf("Detached commit %s is tagged %s on %s", short, strings.Join(remoteTags, ", "), a.remote))
+		target := "<branch>"
+		if len(status.LocalBranches) > 0 {
+			target = status.LocalBranches[0]
+		}
+		result.Actions = append(result.Actions, fmt.Sprintf("Commit is backed up remotely; return to a branch when done: git switch %s", target))
+	case len(status.LocalBranches) > 0 && result.Status == domain.StatusNoRemote:
+		result.Details = append(result.Details, fmt.Sprintf("Detached commit %s is only on local branch(es) %s", short, strings.Join(status.LocalBranches, ", ")))
+		result.Actions = append(result.Actions, fmt.Sprintf("Return to the branch: git switch %s", status.LocalBranches[0]))
+	case len(status.LocalBranches) > 0:
+		result.Details = append(result.Details, fmt.Sprintf("Detached commit %s is only on local branch(es) %s", short, strings.Join(status.LocalBranches, ", ")))
+		result.Actions = append(result.Actions, fmt.Sprintf("Return to the branch and push it: git switch %s", status.LocalBranches[0]))
+	default:
+		result.Details = append(result.Details, fmt.Sprintf("Detached commit %s is not reachable from any branch", short))
+		result.Actions = append(result.Actions, "Create a branch to keep this commit: git switch -c <new-branch>")
+		if result.Status != domain.StatusNoRemote {
+			result.Actions = append(result.Actions, fmt.Sprintf("Then publish it: git push -u %s <new-branch>", a.remote))
+		}
+	}
+}
+
+func (a *Analyzer) remoteTagsAt(ctx context.Context, repoPath string, commit string) []string {
+	pointing, err := a.client.TagsPointingAt(ctx, repoPath, commit)
+	if err != nil || len(pointing) == 0 {
+		return nil
+	}
+	remote, err := a.client.RemoteTags(ctx, repoPath, a.remote)
+	if err != nil {
+		return nil
+	}
+	var tags []string
+	for _, name := range pointing {
+		if remote[name] == commit {
+			tags = append(tags, name)
+		}
+	}
+	return tags
+}
+
+func detachedSwitchTarget(status *domain.DetachedHeadStatus) string {
+	if len(status.LocalBranches) > 0 {
+		return status.LocalBranches[0]
+	}
+	_, branch, _ := strings.Cut(status.RemoteBranches[0], "/")
+	return branch
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+	"github.com/guionardo/git_sync_status/internal/gitclient"
+)
+
+func TestAnalyzerDetachedHead(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		refs         []string
+		pointingAt   []string
+		remoteTags   map[string]string
+		wantOnRemote bool
+		wantAction   string
+	}{
+		{
+			name:         "on remote branch",
+			refs:         []string{"refs/remotes/origin/release", "refs/tags/v1.0.0"},
+			wantOnRemote: true,
+			wantAction:   "git switch release",
+		},
+		{
+			name:       "only local branch",
+			refs:       []string{"refs/heads/feature"},
+			wantAction: "git switch feature",
+		},
+		{
+			name:         "tagged on remote",
+			refs:         []string{"refs/tags/v1.0.0"},
+			pointingAt:   []string{"v1.0.0"},
+			remoteTags:   map[string]string{"v1.0.0": "0123456789abcdef"},
+			wantOnRemote: true,
+			wantAction:   "backed up remotely",
+		},
+		{
+			name:       "tag differs on remote",
+			refs:       []string{"refs/tags/v1.0.0"},
+			pointingAt: []string{"v1.0.0"},
+			remoteTags: map[string]string{"v1.0.0": "fedcba9876543210"},
+			wantAction: "git switch -c <new-branch>",
+		},
+		{
+			name:       "unreachable",
+			wantAction: "git switch -c <new-branch>",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			fc := &fakeClient{
+				isRepo: true, detached: true, hasRemote: true, reachable: true,
+				commitInfos:    map[string]gitclient.RefInfo{"HEAD": {Commit: "0123456789abcdef"}},
+				refsContaining: tc.refs,
+				tagsPointingAt: tc.pointingAt,
+				remoteTags:     tc.remoteTags,
+			}
+			got := NewAnalyzer(fc, "origin").Analyze(context.Background(), "/tmp/repo")
+			if got.Status != domain.StatusDetached {
+				t.Fatalf("got status %s, want %s", got.Status, domain.StatusDetached)
+			}
+			if got.Detached == nil || got.Detached.OnRemote != tc.wantOnRemote {
+				t.Fatalf("unexpected detached status: %+v", got.Detached)
+			}
+			found := false
+			for _, action := range got.Actions {
+				if strings.Contains(action, "push -u origin HEAD") {
+					t.Fatalf("must not suggest pushing HEAD: %v", got.Actions)
+				}
+				if strings.Contains(action, tc.wantAction) {
+					found = true
+				}
+			}
+			if !found {
+				t.Fatalf("actions %v missing %q", got.Actions, tc.wantAction)
+			}
+		})
+	}
+}
+
+func TestAnalyzerDetachedHeadWithoutRemote(t *testing.T) {
+	t.Parallel()
+
+	fc := &fakeClient{
+		isRepo: true, detached: true,
+		commitInfos: map[string]gitclient.RefInfo{"HEAD": {Commit: "0123456789abcdef"}},
+	}
+	got := NewAnalyzer(fc, "origin").Analyze(context.Background(), "/tmp/repo")
+	if got.Status != domain.StatusNoRemote || got.Detached == nil {
+		t.Fatalf("got %s %+v, want NO_REMOTE with detached status", got.Status, got.Detached)
+	}
+	for _, action := range got.Actions {
+		if strings.Contains(action, "git push") {
+			t.Fatalf("must not suggest pushing without a remote: %v", got.Actions)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package service
+
+import (
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+)
+
+type HistoryRecorder interface {
+	Record(result domain.Result, at time.Time) error
+}
+
+func (a *Analyzer) WithHistory(recorder HistoryRecorder) *Analyzer {
+	a.history = recorder
+	return a
+}
+
+func (a *Analyzer) recordHistory(result domain.Result) {
+	if a.history == nil || result.Status == domain.StatusNotAGitRepo {
+		return
+	}
+	_ = a.history.Record(result, a.now())
+}
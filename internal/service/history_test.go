@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+)
+
+type sliceRecorder struct {
+	results []domain.Result
+	times   []time.Time
+}
+
+func (r *sliceRecorder) Record(result domain.Result, at time.Time) error {
+	r.results = append(r.results, result)
+	r.times = append(r.times, at)
+	return nil
+}
+
+func TestAnalyzeRecordsFreshAndCachedResultsInHistory(t *testing.T) {
+	t.Parallel()
+
+	fc := &fakeClient{
+		isRepo: true, currentBranch: "main", hasRemote: true, reachable: true,
+		upstream: "origin/main", behind: 2,
+	}
+	recorder := &sliceRecorder{}
+	cache := &mapCache{entries: map[string]domain.Result{}}
+	analyzer := NewAnalyzer(fc, "origin").WithCache(cache).WithHistory(recorder)
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	analyzer.now = func() time.Time { return now }
+
+	analyzer.Analyze(context.Background(), "/tmp/repo")
+	analyzer.Analyze(context.Background(), "/tmp/repo")
+	if len(recorder.results) != 2 || cache.puts != 1 {
+		t.Fatalf("recorded %d results, puts = %d; want 2 records (cache hit included) and 1 put", len(recorder.results), cache.puts)
+	}
+	for i, got := range recorder.results {
+		if got.Status != domain.StatusLate || got.Behind != 2 || !recorder.times[i].Equal(now) {
+			t.Fatalf("recorded %+v at %s", got, recorder.times[i])
+		}
+	}
+
+	NewAnalyzer(&fakeClient{}, "origin").WithHistory(recorder).Analyze(context.Background(), "/tmp/not-a-repo")
+	if len(recorder.results) != 2 {
+		t.Fatalf("NOT_A_GIT_REPO was recorded")
+	}
+}
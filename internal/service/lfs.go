@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+	"github.com/guionardo/git_sync_status/internal/lfs"
+)
+
+type LFSRemote interface {
+	MissingObjects(ctx context.Context, endpoint lfs.Endpoint, objects []lfs.Object) ([]lfs.Object, error)
+}
+
+func (a *Analyzer) enrichLFS(ctx context.Context, repoPath string, result *domain.Result) {
+	pointers, err := a.client.LFSPointers(ctx, repoPath)
+	if err != nil {
+		result.Details = append(result.Details, fmt.Sprintf("Could not inspect LFS pointers: %v", err))
+		return
+	}
+	if len(pointers) == 0 {
+		return
+	}
+
+	status := &domain.LFSStatus{TrackedFiles: len(pointers)}
+	result.LFS = status
+
+	paths := make(map[string][]string)
+	var local []lfs.Object
+	for _, p := range pointers {
+		if !p.Local {
+			continue
+		}
+		if _, seen := paths[p.OID]; !seen {
+			local = append(local, lfs.Object{OID: p.OID, Size: p.Size})
+		}
+		paths[p.OID] = append(paths[p.OID], p.Path)
+	}
+	status.LocalObjects = len(local)
+	if len(local) == 0 {
+		return
+	}
+
+	server, err := a.client.LFSEndpoint(ctx, repoPath, a.remote)
+	if err != nil {
+		status.Unknown = true
+		result.Details = append(result.Details, fmt.Sprintf("Unpushed LFS objects unknown: could not determine LFS endpoint: %v", err))
+		return
+	}
+	endpoint := lfs.Endpoint{URL: server.URL, Header: server.Header}
+	if len(server.Header) == 0 {
+		if cred, err := a.client.CredentialFill(ctx, repoPath, server.URL); err == nil {
+			endpoint.Username = cred.Username
+			endpoint.Password = cred.Password
+		}
+	}
+	missing, err := a.lfs.MissingObjects(ctx, endpoint, local)
+	if errors.Is(err, lfs.ErrAuthRequired) {
+		status.Unknown = true
+		result.Details = append(result.Details, "Unpushed LFS objects unknown: the LFS server requires credentials (configure a git credential helper or lfs.url)")
+		return
+	}
+	if err != nil {
+		status.Unknown = true
+		result.Details = append(result.Details, fmt.Sprintf("Unpushed LFS objects unknown: could not check LFS objects on remote: %v", err))
+		return
+	}
+	if len(missing) == 0 {
+		return
+	}
+
+	for _, obj := range missing {
+		status.UnpushedObjects++
+		status.UnpushedBytes += obj.Size
+		status.UnpushedPaths = append(status.UnpushedPaths, paths[obj.OID]...)
+	}
+	result.Flags = append(result.Flags, "LFS_UNPUSHED")
+	result.Details = append(result.Details, fmt.Sprintf("%d LFS object(s) (%d bytes) are missing on the remote", status.UnpushedObjects, status.UnpushedBytes))
+	result.Actions = append(result.Actions, fmt.Sprintf("Push LFS objects: git lfs push --all %s", a.remote))
+}
+
+func (a *Analyzer) WithLFSRemote(remote LFSRemote) *Analyzer {
+	if remote != nil {
+		a.lfs = remote
+	}
+	return a
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+	"github.com/guionardo/git_sync_status/internal/gitclient"
+	"github.com/guionardo/git_sync_status/internal/lfs"
+)
+
+type fakeLFSRemote struct {
+	stored   map[string]bool
+	err      error
+	endpoint lfs.Endpoint
+}
+
+func (f *fakeLFSRemote) MissingObjects(_ context.Context, endpoint lfs.Endpoint, objects []lfs.Object) ([]lfs.Object, error) {
+	f.endpoint = endpoint
+	if f.err != nil {
+		return nil, f.err
+	}
+	var missing []lfs.Object
+	for _, obj := range objects {
+		if !f.stored[obj.OID] {
+			missing = append(missing, obj)
+		}
+	}
+	return missing, nil
+}
+
+func TestAnalyzerLFSUnpushed(t *testing.T) {
+	t.Parallel()
+
+	pushed := strings.Repeat("a", 64)
+	unpushed := strings.Repeat("b", 64)
+	fc := &fakeClient{
+		isRepo: true, currentBranch: "main", hasRemote: true, reachable: true,
+		upstream: "origin/main", lfsEndpoint: "https://example.com/repo.git/info/lfs",
+		lfsPointers: []gitclient.LFSPointer{
+			{Path: "assets/a.bin", OID: pushed, Size: 10, Local: true},
+			{Path: "assets/b.bin", OID: unpushed, Size: 20, Local: true},
+			{Path: "assets/c.bin", OID: unpushed, Size: 20, Local: true},
+			{Path: "assets/remote-only.bin", OID: strings.Repeat("c", 64), Size: 30},
+		},
+	}
+
+	analyzer := NewAnalyzer(fc, "origin")
+	analyzer.lfs = &fakeLFSRemote{stored: map[string]bool{pushed: true}}
+	got := analyzer.Analyze(context.Background(), "/tmp/repo")
+
+	if !got.HasFlag("LFS_UNPUSHED") {
+		t.Fatalf("expected LFS_UNPUSHED flag, got %v", got.Flags)
+	}
+	want := domain.LFSStatus{TrackedFiles: 4, LocalObjects: 2, UnpushedObjects: 1, UnpushedBytes: 20}
+	if got.LFS == nil || got.LFS.TrackedFiles != want.TrackedFiles || got.LFS.LocalObjects != want.LocalObjects ||
+		got.LFS.UnpushedObjects != want.UnpushedObjects || got.LFS.UnpushedBytes != want.UnpushedBytes {
+		t.Fatalf("got %+v, want %+v", got.LFS, want)
+	}
+	if len(got.LFS.UnpushedPaths) != 2 {
+		t.Fatalf("got unpushed paths %v, want assets/b.bin and assets/c.bin", got.LFS.UnpushedPaths)
+	}
+}
+
+func TestAnalyzerLFSUsesCredentialsAndReportsUnknownWhenUnauthorized(t *testing.T) {
+	t.Parallel()
+
+	fc := &fakeClient{
+		isRepo: true, currentBranch: "main", hasRemote: true, reachable: true,
+		upstream: "origin/main", lfsEndpoint: "https://example.com/repo.git/info/lfs",
+		lfsPointers: []gitclient.LFSPointer{{Path: "a.bin", OID: strings.Repeat("a", 64), Size: 10, Local: true}},
+		credential:  gitclient.Credential{Username: "alice", Password: "token"},
+	}
+	remote := &fakeLFSRemote{err: fmt.Errorf("%w: 401 Unauthorized", lfs.ErrAuthRequired)}
+	analyzer := NewAnalyzer(fc, "origin").WithLFSRemote(remote)
+	got := analyzer.Analyze(context.Background(), "/tmp/repo")
+
+	want := lfs.Endpoint{URL: fc.lfsEndpoint, Username: "alice", Password: "token"}
+	if !reflect.DeepEqual(remote.endpoint, want) {
+		t.Fatalf("endpoint = %+v, want %+v", remote.endpoint, want)
+	}
+	if got.HasFlag("LFS_UNPUSHED") {
+		t.Fatalf("unexpected LFS_UNPUSHED flag: %v", got.Flags)
+	}
+	if got.LFS == nil || !got.LFS.Unknown || !strings.Contains(strings.Join(got.Details, "\n"), "Unpushed LFS objects unknown") {
+		t.Fatalf("LFS = %+v, details = %v; want an unknown LFS status", got.LFS, got.Details)
+	}
+}
+
+func TestAnalyzerLFSUsesSSHAuthHeaderInsteadOfCredentials(t *testing.T) {
+	t.Parallel()
+
+	fc := &fakeClient{
+		isRepo: true, currentBranch: "main", hasRemote: true, reachable: true,
+		upstream: "origin/main", lfsEndpoint: "https://lfs.example.com/org/repo",
+		lfsHeader:   map[string]string{"Authorization": "RemoteAuth token"},
+		lfsPointers: []gitclient.LFSPointer{{Path: "a.bin", OID: strings.Repeat("a", 64), Size: 10, Local: true}},
+		credential:  gitclient.Credential{Username: "alice", Password: "token"},
+	}
+	remote := &fakeLFSRemote{stored: map[string]bool{strings.Repeat("a", 64): true}}
+	got := NewAnalyzer(fc, "origin").WithLFSRemote(remote).Analyze(context.Background(), "/tmp/repo")
+
+	if remote.endpoint.Header["Authorization"] != "RemoteAuth token" || remote.endpoint.Password != "" {
+		t.Fatalf("endpoint = %+v, want the SSH header and no credential helper password", remote.endpoint)
+	}
+	if got.LFS == nil || got.LFS.Unknown || got.HasFlag("LFS_UNPUSHED") {
+		t.Fatalf("LFS = %+v, flags = %v; want a checked, fully pushed status", got.LFS, got.Flags)
+	}
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"time"
+)
+
+type LostCommit struct {
+	SHA     string
+	Author  string
+	Date    time.Time
+	Subject string
+}
+
+type LostWorkGroup struct {
+	Source  string
+	Commits []LostCommit
+}
+
+type LostWorkAudit struct {
+	RepoPath     string
+	Remote       string
+	Groups       []LostWorkGroup
+	TotalCommits int
+}
+
+func (a *Analyzer) AuditLostWork(ctx context.Context, repoPath string) (LostWorkAudit, error) {
+	audit := LostWorkAudit{RepoPath: repoPath, Remote: a.remote}
+	seen := make(map[string]bool)
+
+	branches, err := a.client.LocalBranches(ctx, repoPath)
+	if err != nil {
+		return audit, err
+	}
+	for _, branch := range branches {
+		if err := a.addLostWorkGroup(ctx, repoPath, &audit, seen, branch, []string{"refs/heads/" + branch}, []string{"--remotes"}); err != nil {
+			return audit, err
+		}
+	}
+
+	headReflog, err := a.client.ReflogCommits(ctx, repoPath, "HEAD")
+	if err != nil {
+		return audit, err
+	}
+	if err := a.addLostWorkGroup(ctx, repoPath, &audit, seen, "HEAD reflog", headReflog, []string{"--remotes", "--branches"}); err != nil {
+		return audit, err
+	}
+
+	stashes, err := a.client.ReflogCommits(ctx, repoPath, "refs/stash")
+	if err != nil {
+		return audit, err
+	}
+	if err := a.addLostWorkGroup(ctx, repoPath, &audit, seen, "stash", stashes, []string{"--remotes"}); err != nil {
+		return audit, err
+	}
+
+	return audit, nil
+}
+
+func (a *Analyzer) addLostWorkGroup(ctx context.Context, repoPath string, audit *LostWorkAudit, seen map[string]bool, source string, tips []string, excludes []string) error {
+	commits, err := a.client.CommitsNotIn(ctx, repoPath, tips, excludes)
+	if err != nil {
+		return err
+	}
+	group := LostWorkGroup{Source: source}
+	for _, c := range commits {
+		if seen[c.SHA] {
+			continue
+		}
+		seen[c.SHA] = true
+		group.Commits = append(group.Commits, LostCommit{SHA: c.SHA, Author: c.AuthorName, Date: c.Date, Subject: c.Subject})
+	}
+	if len(group.Commits) > 0 {
+		audit.Groups = append(audit.Groups, group)
+		audit.TotalCommits += len(group.Commits)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/guionardo/git_sync_status/internal/gitclient"
+)
+
+func TestAuditLostWork(t *testing.T) {
+	t.Parallel()
+
+	fc := &fakeClient{
+		branches: []string{"main", "feature"},
+		reflogs: map[string][]string{
+			"HEAD":       {"orphan"},
+			"refs/stash": {"stash1"},
+		},
+		commitsNotIn: map[string][]gitclient.Commit{
+			"refs/heads/main":    {{SHA: "c1", Subject: "local fix"}},
+			"refs/heads/feature": {{SHA: "c2", Subject: "feature work"}, {SHA: "c1", Subject: "local fix"}},
+			"orphan":             {{SHA: "c3", Subject: "detached experiment"}},
+			"stash1":             {{SHA: "c4", Subject: "WIP on main"}},
+		},
+	}
+
+	audit, err := NewAnalyzer(fc, "origin").AuditLostWork(context.Background(), "/tmp/repo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if audit.TotalCommits != 4 {
+		t.Fatalf("got %d commits, want 4: %+v", audit.TotalCommits, audit.Groups)
+	}
+	wantSources := []string{"main", "feature", "HEAD reflog", "stash"}
+	if len(audit.Groups) != len(wantSources) {
+		t.Fatalf("got %d groups, want %d", len(audit.Groups), len(wantSources))
+	}
+	for i, want := range wantSources {
+		if audit.Groups[i].Source != want {
+			t.Fatalf("group %d: got %s, want %s", i, audit.Groups[i].Source, want)
+		}
+	}
+	if len(audit.Groups[1].Commits) != 1 || audit.Groups[1].Commits[0].SHA != "c2" {
+		t.Fatalf("shared commits must be reported once: %+v", audit.Groups[1])
+	}
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+)
+
+type PushUpdate struct {
+	LocalRef  string
+	LocalSHA  string
+	RemoteRef string
+	RemoteSHA string
+}
+
+func (u PushUpdate) Deletes() bool {
+	return isZeroSHA(u.LocalSHA)
+}
+
+func (u PushUpdate) CreatesRef() bool {
+	return isZeroSHA(u.RemoteSHA)
+}
+
+func (u PushUpdate) PushesBranch() bool {
+	return strings.HasPrefix(u.LocalRef, "refs/heads/") && !u.Deletes()
+}
+
+func (a *Analyzer) AnalyzePush(ctx context.Context, repoPath string, update PushUpdate) (domain.Result, bool) {
+	if !update.PushesBranch() {
+		return domain.Result{}, false
+	}
+	result := domain.Result{
+		RepoPath: repoPath,
+		Branch:   strings.TrimPrefix(update.LocalRef, "refs/heads/"),
+		Upstream: a.remote + "/" + strings.TrimPrefix(update.RemoteRef, "refs/heads/"),
+	}
+
+	revs := []string{update.LocalSHA, "--not", "--remotes"}
+	switch {
+	case update.CreatesRef():
+		result.Status = domain.StatusSyncPending
+	case !a.hasCommit(ctx, repoPath, update.RemoteSHA):
+		result.Status = domain.StatusLate
+		result.Details = append(result.Details, fmt.Sprintf("%s has commits that were not fetched", result.Upstream))
+	default:
+		behind, ahead, err := a.client.AheadBehindRefs(ctx, repoPath, update.RemoteSHA, update.LocalSHA)
+		if err != nil {
+			result.Err = err.Error()
+			result.Status = domain.StatusSyncPending
+			break
+		}
+		result.Behind = behind
+		result.Ahead = ahead
+		switch {
+		case behind > 0 && ahead > 0:
+			result.Status = domain.StatusDiverged
+		case behind > 0:
+			result.Status = domain.StatusLate
+		case ahead > 0:
+			result.Status = domain.StatusSyncPending
+		default:
+			result.Status = domain.StatusSynced
+		}
+		revs = []string{update.RemoteSHA + ".." + update.LocalSHA}
+	}
+
+	findings, err := a.scanOutgoing(ctx, repoPath, revs)
+	if err != nil {
+		result.Details = append(result.Details, fmt.Sprintf("Could not scan outgoing changes: %v", err))
+	}
+	if len(findings) > 0 {
+		result.Findings = findings
+		result.Flags = append(result.Flags, "RISKY_PUSH")
+	}
+	return result, true
+}
+
+func (a *Analyzer) hasCommit(ctx context.Context, repoPath string, sha string) bool {
+	_, err := a.client.CommitInfo(ctx, repoPath, sha)
+	return err == nil
+}
+
+func isZeroSHA(sha string) bool {
+	return sha != "" && strings.Trim(sha, "0") == ""
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"time"
+)
+
+type RemoteBranchStatus struct {
+	Branch           string
+	LastCommitAuthor string
+	LastCommitDate   time.Time
+	MergedInto       string
+	Merged           bool
+}
+
+func (a *Analyzer) ScanRemoteOnlyBranches(ctx context.Context, repoPath string) ([]RemoteBranchStatus, error) {
+	remoteBranches, err := a.client.RemoteBranches(ctx, repoPath, a.remote)
+	if err != nil {
+		return nil, err
+	}
+
+	localBranches, err := a.client.LocalBranches(ctx, repoPath)
+	if err != nil {
+		return nil, err
+	}
+	tracked := make(map[string]bool, len(localBranches))
+	for _, branch := range localBranches {
+		upstream, err := a.client.UpstreamForBranch(ctx, repoPath, branch)
+		if err != nil {
+			continue
+		}
+		tracked[upstream] = true
+	}
+
+	base := a.defaultRemoteBranch(ctx, repoPath)
+
+	rows := make([]RemoteBranchStatus, 0, len(remoteBranches))
+	for _, ref := range remoteBranches {
+		if tracked[ref.Name] || ref.Name == base {
+			continue
+		}
+		row := RemoteBranchStatus{
+			Branch:           ref.Name,
+			LastCommitAuthor: ref.AuthorName,
+			LastCommitDate:   ref.CommitDate,
+		}
+		if base != "" {
+			merged, err := a.client.IsAncestor(ctx, repoPath, ref.Name, base)
+			if err == nil {
+				row.MergedInto = base
+				row.Merged = merged
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
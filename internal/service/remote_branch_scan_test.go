@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/gitclient"
+)
+
+func TestScanRemoteOnlyBranches(t *testing.T) {
+	t.Parallel()
+
+	lastWeek := time.Now().Add(-7 * 24 * time.Hour)
+	fc := &fakeClient{
+		branches:      []string{"main"},
+		upstream:      "origin/main",
+		defaultBranch: "main",
+		remoteBranches: []gitclient.RefInfo{
+			{Name: "origin/main", AuthorName: "alice"},
+			{Name: "origin/feature/x", AuthorName: "bob", CommitDate: lastWeek},
+			{Name: "origin/old", AuthorName: "carol"},
+		},
+		ancestors: map[string]bool{"origin/old": true},
+	}
+
+	analyzer := NewAnalyzer(fc, "origin")
+	rows, err := analyzer.ScanRemoteOnlyBranches(context.Background(), "/tmp/repo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2: %+v", len(rows), rows)
+	}
+	if rows[0].Branch != "origin/feature/x" || rows[0].Merged || rows[0].LastCommitAuthor != "bob" || !rows[0].LastCommitDate.Equal(lastWeek) {
+		t.Fatalf("unexpected first row: %+v", rows[0])
+	}
+	if rows[1].Branch != "origin/old" || !rows[1].Merged || rows[1].MergedInto != "origin/main" {
+		t.Fatalf("unexpected second row: %+v", rows[1])
+	}
+}
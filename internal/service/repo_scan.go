@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+)
+
+const (
+	DefaultScanDepth   = 3
+	DefaultScanWorkers = 4
+)
+
+func DiscoverRepositories(root string, maxDepth int) ([]string, error) {
+	if maxDepth <= 0 {
+		maxDepth = DefaultScanDepth
+	}
+	root = filepath.Clean(root)
+	var repos []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			return fs.SkipDir
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != root && strings.HasPrefix(d.Name(), ".") {
+			return fs.SkipDir
+		}
+		if _, err := os.Stat(filepath.Join(path, ".git")); err == nil {
+			repos = append(repos, path)
+			return fs.SkipDir
+		}
+		rel, _ := filepath.Rel(root, path)
+		if rel != "." && strings.Count(rel, string(filepath.Separator))+1 >= maxDepth {
+			return fs.SkipDir
+		}
+		return nil
+	})
+	sort.Strings(repos)
+	return repos, err
+}
+
+func (a *Analyzer) AnalyzeRepositories(ctx context.Context, repoPaths []string) []domain.Result {
+	results := make([]domain.Result, 0, len(repoPaths))
+	for _, path := range repoPaths {
+		results = append(results, a.Analyze(ctx, path))
+	}
+	return results
+}
+
+func (a *Analyzer) StreamRepositories(ctx context.Context, repoPaths []string, workers int, fn func(domain.Result)) {
+	if workers <= 0 {
+		workers = 1
+	}
+	paths := make(chan string)
+	results := make(chan domain.Result)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for path := range paths {
+				results <- a.Analyze(ctx, path)
+			}
+		}()
+	}
+	go func() {
+		for _, path := range repoPaths {
+			paths <- path
+		}
+		close(paths)
+		wg.Wait()
+		close(results)
+	}()
+
+	for result := range results {
+		fn(result)
+	}
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+)
+
+func TestDiscoverRepositories(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	for _, dir := range []string{
+		"a/.git",
+		"group/b/.git",
+		"group/b/nested/.git",
+		"deep/one/two/three/.git",
+		".hidden/c/.git",
+	} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(root, "worktree"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "worktree", ".git"), []byte("gitdir: ../a/.git/worktrees/w\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := DiscoverRepositories(root, 3)
+	if err != nil {
+		t.Fatalf("DiscoverRepositories() error = %v", err)
+	}
+	want := []string{
+		filepath.Join(root, "a"),
+		filepath.Join(root, "group", "b"),
+		filepath.Join(root, "worktree"),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("DiscoverRepositories() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("DiscoverRepositories() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestStreamRepositoriesDeliversEveryResult(t *testing.T) {
+	t.Parallel()
+
+	fc := &fakeClient{isRepo: true, currentBranch: "main", hasRemote: true, reachable: true, upstream: "origin/main"}
+	paths := []string{"/src/a", "/src/b", "/src/c", "/src/d", "/src/e"}
+
+	seen := map[string]bool{}
+	NewAnalyzer(fc, "origin").StreamRepositories(context.Background(), paths, 3, func(result domain.Result) {
+		seen[result.RepoPath] = true
+	})
+	if len(seen) != len(paths) {
+		t.Fatalf("got results for %v, want %v", seen, paths)
+	}
+}
+
+func TestBuildReportIncludesBranchRows(t *testing.T) {
+	t.Parallel()
+
+	fc := &fakeClient{
+		isRepo: true, currentBranch: "main", hasRemote: true, reachable: true,
+		upstream: "origin/main", branches: []string{"main", "feature"},
+	}
+	report := NewAnalyzer(fc, "origin").BuildReport(context.Background(), []string{"/src/a", "/src/b"})
+	if len(report.Repositories) != 2 || report.GeneratedAt.IsZero() {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	if got := report.Repositories[1]; got.Result.RepoPath != "/src/b" || len(got.Branches) != 2 {
+		t.Fatalf("unexpected repository report %+v", got)
+	}
+}
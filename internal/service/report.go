@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+)
+
+type Report struct {
+	GeneratedAt  time.Time
+	Repositories []RepositoryReport
+}
+
+type RepositoryReport struct {
+	Result   domain.Result
+	Branches []BranchStatus
+	Err      string
+}
+
+func (a *Analyzer) BuildReport(ctx context.Context, repoPaths []string) Report {
+	report := Report{GeneratedAt: a.now()}
+	for _, path := range repoPaths {
+		repo := RepositoryReport{Result: a.Analyze(ctx, path)}
+		if repo.Result.Status != domain.StatusNotAGitRepo {
+			branches, err := a.AnalyzeAllBranches(ctx, path)
+			if err != nil {
+				repo.Err = err.Error()
+			}
+			repo.Branches = branches
+		}
+		report.Repositories = append(report.Repositories, repo)
+	}
+	return report
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+)
+
+type ResultCache interface {
+	Get(repoPath string, variant string) (domain.Result, bool)
+	Put(repoPath string, variant string, result domain.Result) error
+}
+
+func (a *Analyzer) WithCache(cache ResultCache) *Analyzer {
+	a.cache = cache
+	return a
+}
+
+func (a *Analyzer) cacheVariant() string {
+	return fmt.Sprintf("remote=%s stale=%d max-file-size=%d", a.remote, a.options.StaleAfter, a.options.MaxFileSize)
+}
+
+func cacheable(result domain.Result) bool {
+	return result.Status != domain.StatusNotAGitRepo && !result.HasFlag("REMOTE_UNREACHABLE")
+}
+
+func (a *Analyzer) refreshWorktreeState(ctx context.Context, repoPath string, result *domain.Result) {
+	result.Flags = without(result.Flags, "WORKTREE_DIRTY")
+	result.Details = without(result.Details, worktreeDirtyDetail)
+	result.Actions = without(result.Actions, reviewLocalChangesAction)
+
+	a.enrichWorktreeState(ctx, repoPath, result)
+	if result.Upstream != "" && result.HasFlag("WORKTREE_DIRTY") {
+		result.Actions = append(result.Actions, reviewLocalChangesAction)
+	}
+}
+
+func without(values []string, drop string) []string {
+	return slices.DeleteFunc(slices.Clone(values), func(value string) bool { return value == drop })
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+)
+
+type mapCache struct {
+	entries map[string]domain.Result
+	puts    int
+}
+
+func (c *mapCache) Get(repoPath string, variant string) (domain.Result, bool) {
+	result, ok := c.entries[repoPath+"|"+variant]
+	return result, ok
+}
+
+func (c *mapCache) Put(repoPath string, variant string, result domain.Result) error {
+	c.entries[repoPath+"|"+variant] = result
+	c.puts++
+	return nil
+}
+
+func TestAnalyzeReusesCachedResult(t *testing.T) {
+	t.Parallel()
+
+	fc := &fakeClient{
+		isRepo: true, currentBranch: "main", hasRemote: true, reachable: true,
+		upstream: "origin/main", ahead: 1,
+	}
+	cache := &mapCache{entries: map[string]domain.Result{}}
+	analyzer := NewAnalyzer(fc, "origin").WithCache(cache)
+
+	first := analyzer.Analyze(context.Background(), "/tmp/repo")
+	if first.Status != domain.StatusSyncPending || cache.puts != 1 {
+		t.Fatalf("first Analyze() = %s, puts = %d", first.Status, cache.puts)
+	}
+
+	fc.ahead = 0
+	second := analyzer.Analyze(context.Background(), "/tmp/repo")
+	if second.Status != domain.StatusSyncPending || cache.puts != 1 {
+		t.Fatalf("second Analyze() = %s, puts = %d; want cached SYNC_PENDING", second.Status, cache.puts)
+	}
+
+	other := NewAnalyzer(fc, "upstream").WithCache(cache).Analyze(context.Background(), "/tmp/repo")
+	if other.Status != domain.StatusSynced {
+		t.Fatalf("Analyze() with another remote = %s, want fresh SYNCED", other.Status)
+	}
+}
+
+func TestAnalyzeBypassesCacheWhenFetchFails(t *testing.T) {
+	t.Parallel()
+
+	fc := &fakeClient{
+		isRepo: true, currentBranch: "main", hasRemote: true, reachable: true,
+		upstream: "origin/main", ahead: 1,
+	}
+	cache := &mapCache{entries: map[string]domain.Result{}}
+	analyzer := NewAnalyzer(fc, "origin").WithCache(cache)
+	analyzer.Analyze(context.Background(), "/tmp/repo")
+
+	fc.fetchErr = errors.New("network down")
+	got := analyzer.Analyze(context.Background(), "/tmp/repo")
+	if !got.HasFlag("REMOTE_UNREACHABLE") || cache.puts != 1 {
+		t.Fatalf("flags = %v, puts = %d; want a fresh REMOTE_UNREACHABLE result", got.Flags, cache.puts)
+	}
+}
+
+func TestAnalyzeDoesNotCacheUnreachableRemote(t *testing.T) {
+	t.Parallel()
+
+	fc := &fakeClient{isRepo: true, currentBranch: "main", hasRemote: true, upstream: "origin/main"}
+	cache := &mapCache{entries: map[string]domain.Result{}}
+	got := NewAnalyzer(fc, "origin").WithCache(cache).Analyze(context.Background(), "/tmp/repo")
+	if !got.HasFlag("REMOTE_UNREACHABLE") || cache.puts != 0 {
+		t.Fatalf("flags = %v, puts = %d; want uncached REMOTE_UNREACHABLE", got.Flags, cache.puts)
+	}
+}
+
+func TestAnalyzeRechecksWorktreeOnCacheHit(t *testing.T) {
+	t.Parallel()
+
+	fc := &fakeClient{
+		isRepo: true, currentBranch: "main", hasRemote: true, reachable: true,
+		upstream: "origin/main",
+	}
+	cache := &mapCache{entries: map[string]domain.Result{}}
+	analyzer := NewAnalyzer(fc, "origin").WithCache(cache)
+
+	if first := analyzer.Analyze(context.Background(), "/tmp/repo"); first.HasFlag("WORKTREE_DIRTY") {
+		t.Fatalf("first Analyze() flags = %v, want clean worktree", first.Flags)
+	}
+
+	fc.dirty = true
+	dirty := analyzer.Analyze(context.Background(), "/tmp/repo")
+	if !dirty.HasFlag("WORKTREE_DIRTY") || !slices.Contains(dirty.Actions, reviewLocalChangesAction) {
+		t.Fatalf("cached Analyze() flags = %v, actions = %v; want fresh WORKTREE_DIRTY", dirty.Flags, dirty.Actions)
+	}
+
+	fc.dirty = false
+	clean := analyzer.Analyze(context.Background(), "/tmp/repo")
+	if clean.HasFlag("WORKTREE_DIRTY") || slices.Contains(clean.Actions, reviewLocalChangesAction) || cache.puts != 1 {
+		t.Fatalf("cached Analyze() flags = %v, actions = %v, puts = %d; want clean cached result", clean.Flags, clean.Actions, cache.puts)
+	}
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+	"github.com/guionardo/git_sync_status/internal/gitclient"
+)
+
+const (
+	RuleLargeFile     = "LARGE_FILE"
+	RuleEnvFile       = "ENV_FILE"
+	RulePrivateKey    = "PRIVATE_KEY"
+	RuleAWSAccessKey  = "AWS_ACCESS_KEY"
+	RuleAWSSecretKey  = "AWS_SECRET_KEY"
+	maxFindingDetails = 5
+)
+
+var (
+	privateKeyPattern   = regexp.MustCompile(`-----BEGIN ([A-Z]+ )?PRIVATE KEY( BLOCK)?-----`)
+	awsAccessKeyPattern = regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`)
+	awsSecretKeyPattern = regexp.MustCompile(`(?i)aws_?secret_?access_?key["']?\s*[:=]\s*["']?[A-Za-z0-9/+=]{40}\b`)
+	envFileExemptions   = map[string]bool{".env.example": true, ".env.sample": true, ".env.template": true, ".env.dist": true}
+)
+
+func (a *Analyzer) enrichOutgoingRisks(ctx context.Context, repoPath string, result *domain.Result) {
+	revs := []string{result.Upstream + "..HEAD"}
+	action := fmt.Sprintf("Remove risky files from outgoing commits before pushing: git rebase -i %s", result.Upstream)
+	if result.Upstream == "" {
+		revs = []string{"HEAD", "--not", "--remotes"}
+		action = "Remove risky files from commits not yet on any remote before the first push"
+	}
+	findings, err := a.scanOutgoing(ctx, repoPath, revs)
+	if err != nil {
+		result.Details = append(result.Details, fmt.Sprintf("Could not scan outgoing changes: %v", err))
+		return
+	}
+	if len(findings) == 0 {
+		return
+	}
+
+	result.Findings = findings
+	result.Flags = append(result.Flags, "RISKY_PUSH")
+	for i, f := range findings {
+		if i == maxFindingDetails {
+			result.Details = append(result.Details, fmt.Sprintf("... and %d more risky change(s)", len(findings)-maxFindingDetails))
+			break
+		}
+		result.Details = append(result.Details, fmt.Sprintf("%s in %s (%s): %s", f.Rule, f.Path, shortSHA(f.Commit), f.Detail))
+	}
+	result.Actions = append(result.Actions, action)
+}
+
+func (a *Analyzer) scanOutgoing(ctx context.Context, repoPath string, revs []string) ([]domain.RiskFinding, error) {
+	files, err := a.client.OutgoingFiles(ctx, repoPath, revs)
+	if err != nil {
+		return nil, fmt.Errorf("list outgoing files: %w", err)
+	}
+	patches, err := a.client.OutgoingPatches(ctx, repoPath, revs)
+	if err != nil {
+		return nil, fmt.Errorf("inspect outgoing changes: %w", err)
+	}
+
+	findings := scanOutgoingFiles(files, a.options.MaxFileSize)
+	return append(findings, scanOutgoingPatches(patches)...), nil
+}
+
+func scanOutgoingFiles(files []gitclient.OutgoingFile, maxSize int64) []domain.RiskFinding {
+	var findings []domain.RiskFinding
+	for _, f := range files {
+		if maxSize > 0 && f.Size > maxSize {
+			findings = append(findings, domain.RiskFinding{
+				Rule: RuleLargeFile, Commit: f.Commit, Path: f.Path,
+				Detail: fmt.Sprintf("%d bytes exceeds the %d byte limit", f.Size, maxSize),
+			})
+		}
+		if isEnvFile(f.Path) {
+			findings = append(findings, domain.RiskFinding{
+				Rule: RuleEnvFile, Commit: f.Commit, Path: f.Path,
+				Detail: "environment files usually contain credentials",
+			})
+		}
+	}
+	return findings
+}
+
+func scanOutgoingPatches(patches []gitclient.FilePatch) []domain.RiskFinding {
+	rules := []struct {
+		name    string
+		pattern *regexp.Regexp
+		detail  string
+	}{
+		{RulePrivateKey, privateKeyPattern, "private key block added"},
+		{RuleAWSAccessKey, awsAccessKeyPattern, "AWS access key id added"},
+		{RuleAWSSecretKey, awsSecretKeyPattern, "AWS secret access key added"},
+	}
+
+	var findings []domain.RiskFinding
+	for _, p := range patches {
+		for _, rule := range rules {
+			for _, line := range p.Added {
+				if rule.pattern.MatchString(line) {
+					findings = append(findings, domain.RiskFinding{Rule: rule.name, Commit: p.Commit, Path: p.Path, Detail: rule.detail})
+					break
+				}
+			}
+		}
+	}
+	return findings
+}
+
+func isEnvFile(file string) bool {
+	base := path.Base(file)
+	if envFileExemptions[base] {
+		return false
+	}
+	return base == ".env" || strings.HasPrefix(base, ".env.")
+}
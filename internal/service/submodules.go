@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+)
+
+func (a *Analyzer) enrichSubmodules(ctx context.Context, repoPath string, result *domain.Result) {
+	submodules, err := a.client.Submodules(ctx, repoPath)
+	if err != nil {
+		result.Details = append(result.Details, fmt.Sprintf("Could not inspect submodules: %v", err))
+		return
+	}
+	if len(submodules) == 0 {
+		return
+	}
+
+	inSync := true
+	uninitialized := false
+	for _, sm := range submodules {
+		status := domain.SubmoduleStatus{
+			Path:             sm.Path,
+			RecordedCommit:   sm.RecordedCommit,
+			CheckedOutCommit: sm.CheckedOutCommit,
+			Initialized:      sm.Initialized,
+			AtRecordedCommit: sm.AtRecordedCommit(),
+		}
+		if sm.Initialized {
+			sub := a.AnalyzeLocal(ctx, filepath.Join(repoPath, sm.Path))
+			status.Dirty = sub.HasFlag("WORKTREE_DIRTY")
+			status.Branch = sub.Branch
+			status.Upstream = sub.Upstream
+			status.Status = sub.Status
+			status.Behind = sub.Behind
+			status.Ahead = sub.Ahead
+			status.Flags = sub.Flags
+			status.Submodules = sub.Submodules
+		} else {
+			uninitialized = true
+		}
+		if !status.InSync() {
+			inSync = false
+		}
+		result.Submodules = append(result.Submodules, status)
+	}
+
+	if inSync {
+		return
+	}
+	result.Flags = append(result.Flags, "SUBMODULES_OUT_OF_SYNC")
+	result.Details = append(result.Details, "One or more submodules are uninitialized, not at the recorded commit, or have local changes")
+	if uninitialized {
+		result.Actions = append(result.Actions, "Initialize submodules: git submodule update --init --recursive")
+	} else {
+		result.Actions = append(result.Actions, "Review submodules: git submodule status --recursive")
+	}
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/guionardo/git_sync_status/internal/gitclient"
+)
+
+func TestAnalyzerSubmodules(t *testing.T) {
+	t.Parallel()
+
+	fc := &fakeClient{
+		isRepo: true, currentBranch: "main", hasRemote: true, reachable: true,
+		upstream: "origin/main",
+		submodules: map[string][]gitclient.Submodule{
+			"/tmp/repo": {
+				{Path: "libs/a", RecordedCommit: "abc", CheckedOutCommit: "abc", Initialized: true},
+				{Path: "libs/b", RecordedCommit: "def"},
+			},
+			"/tmp/repo/libs/a": {
+				{Path: "nested", RecordedCommit: "111", CheckedOutCommit: "222", Initialized: true},
+			},
+		},
+	}
+
+	got := NewAnalyzer(fc, "origin").Analyze(context.Background(), "/tmp/repo")
+	if !got.HasFlag("SUBMODULES_OUT_OF_SYNC") {
+		t.Fatalf("expected SUBMODULES_OUT_OF_SYNC flag, got %v", got.Flags)
+	}
+	if len(got.Submodules) != 2 {
+		t.Fatalf("got %d submodules, want 2", len(got.Submodules))
+	}
+
+	a := got.Submodules[0]
+	if !a.Initialized || !a.AtRecordedCommit || a.Status != "SYNCED" {
+		t.Fatalf("unexpected libs/a status: %+v", a)
+	}
+	if len(a.Submodules) != 1 || a.Submodules[0].AtRecordedCommit || a.InSync() {
+		t.Fatalf("expected nested submodule off its recorded commit: %+v", a.Submodules)
+	}
+
+	b := got.Submodules[1]
+	if b.Initialized || b.Status != "" {
+		t.Fatalf("unexpected libs/b status: %+v", b)
+	}
+}
+
+type networkRecordingClient struct {
+	*fakeClient
+	network []string
+}
+
+func (c *networkRecordingClient) FetchPrune(ctx context.Context, path string, remote string) error {
+	c.network = append(c.network, "fetch "+path)
+	return c.fakeClient.FetchPrune(ctx, path, remote)
+}
+
+func (c *networkRecordingClient) RemoteReachable(ctx context.Context, path string, remote string) (bool, error) {
+	c.network = append(c.network, "reachable "+path)
+	return c.fakeClient.RemoteReachable(ctx, path, remote)
+}
+
+func TestAnalyzerSubmodulesStayLocal(t *testing.T) {
+	t.Parallel()
+
+	client := &networkRecordingClient{fakeClient: &fakeClient{
+		isRepo: true, currentBranch: "main", hasRemote: true, reachable: true,
+		upstream: "origin/main",
+		submodules: map[string][]gitclient.Submodule{
+			"/tmp/repo": {{Path: "libs/a", RecordedCommit: "abc", CheckedOutCommit: "abc", Initialized: true}},
+		},
+	}}
+	recorder := &sliceRecorder{}
+
+	got := NewAnalyzer(client, "origin").WithHistory(recorder).Analyze(context.Background(), "/tmp/repo")
+	if len(got.Submodules) != 1 || got.Submodules[0].Status != "SYNCED" {
+		t.Fatalf("unexpected submodules: %+v", got.Submodules)
+	}
+	want := []string{"reachable /tmp/repo", "fetch /tmp/repo"}
+	if len(client.network) != len(want) || client.network[0] != want[0] || client.network[1] != want[1] {
+		t.Fatalf("network calls = %v, want %v", client.network, want)
+	}
+	if len(recorder.results) != 1 {
+		t.Fatalf("recorded %d results, want only the top-level repository", len(recorder.results))
+	}
+}
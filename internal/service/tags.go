@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+)
+
+func (a *Analyzer) enrichTags(ctx context.Context, repoPath string, result *domain.Result) {
+	local, err := a.client.LocalTags(ctx, repoPath)
+	if err != nil {
+		result.Details = append(result.Details, fmt.Sprintf("Could not list local tags: %v", err))
+		return
+	}
+	remote, err := a.client.RemoteTags(ctx, repoPath, a.remote)
+	if err != nil {
+		result.Details = append(result.Details, fmt.Sprintf("Could not list remote tags: %v", err))
+		return
+	}
+	if len(local) == 0 && len(remote) == 0 {
+		return
+	}
+
+	tags := &domain.TagStatus{}
+	for name, commit := range local {
+		remoteCommit, ok := remote[name]
+		switch {
+		case !ok:
+			tags.LocalOnly = append(tags.LocalOnly, name)
+		case remoteCommit != commit:
+			tags.Mismatched = append(tags.Mismatched, domain.TagMismatch{Name: name, LocalCommit: commit, RemoteCommit: remoteCommit})
+		}
+	}
+	for name := range remote {
+		if _, ok := local[name]; !ok {
+			tags.RemoteOnly = append(tags.RemoteOnly, name)
+		}
+	}
+	sort.Strings(tags.LocalOnly)
+	sort.Strings(tags.RemoteOnly)
+	sort.Slice(tags.Mismatched, func(i, j int) bool { return tags.Mismatched[i].Name < tags.Mismatched[j].Name })
+	result.Tags = tags
+
+	if len(tags.LocalOnly) > 0 {
+		result.Flags = append(result.Flags, "TAGS_UNPUSHED")
+		if len(tags.LocalOnly) == 1 {
+			result.Actions = append(result.Actions, fmt.Sprintf("Push tag: git push %s %s", a.remote, tags.LocalOnly[0]))
+		} else {
+			result.Actions = append(result.Actions, fmt.Sprintf("Push tags: git push %s %s", a.remote, strings.Join(tags.LocalOnly, " ")))
+		}
+	}
+	if len(tags.RemoteOnly) > 0 {
+		result.Flags = append(result.Flags, "TAGS_MISSING_LOCALLY")
+		result.Actions = append(result.Actions, fmt.Sprintf("Fetch tags: git fetch %s --tags", a.remote))
+	}
+	for _, m := range tags.Mismatched {
+		if !result.HasFlag("TAGS_MISMATCHED") {
+			result.Flags = append(result.Flags, "TAGS_MISMATCHED")
+		}
+		result.Details = append(result.Details, fmt.Sprintf("Tag %s points to %s locally but %s on %s", m.Name, shortSHA(m.LocalCommit), shortSHA(m.RemoteCommit), a.remote))
+		result.Actions = append(result.Actions, fmt.Sprintf("Replace local tag with the remote one: git fetch %s --force tag %s", a.remote, m.Name))
+	}
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"testing"
+)
+
+func TestAnalyzerTags(t *testing.T) {
+	t.Parallel()
+
+	fc := &fakeClient{
+		isRepo: true, currentBranch: "main", hasRemote: true, reachable: true,
+		upstream:   "origin/main",
+		localTags:  map[string]string{"v1.0.0": "aaa", "v1.1.0": "bbb", "v2.0.0-rc1": "ccc"},
+		remoteTags: map[string]string{"v1.0.0": "aaa", "v1.1.0": "ddd", "v0.9.0": "eee"},
+	}
+
+	got := NewAnalyzer(fc, "origin").Analyze(context.Background(), "/tmp/repo")
+	if got.Tags == nil {
+		t.Fatalf("expected tag status")
+	}
+	if len(got.Tags.LocalOnly) != 1 || got.Tags.LocalOnly[0] != "v2.0.0-rc1" {
+		t.Fatalf("unexpected local-only tags: %v", got.Tags.LocalOnly)
+	}
+	if len(got.Tags.RemoteOnly) != 1 || got.Tags.RemoteOnly[0] != "v0.9.0" {
+		t.Fatalf("unexpected remote-only tags: %v", got.Tags.RemoteOnly)
+	}
+	if len(got.Tags.Mismatched) != 1 || got.Tags.Mismatched[0].Name != "v1.1.0" || got.Tags.Mismatched[0].RemoteCommit != "ddd" {
+		t.Fatalf("unexpected mismatched tags: %+v", got.Tags.Mismatched)
+	}
+	for _, flag := range []string{"TAGS_UNPUSHED", "TAGS_MISSING_LOCALLY", "TAGS_MISMATCHED"} {
+		if !got.HasFlag(flag) {
+			t.Fatalf("expected %s flag, got %v", flag, got.Flags)
+		}
+	}
+}
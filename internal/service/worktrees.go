@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+)
+
+func (a *Analyzer) enrichWorktrees(ctx context.Context, repoPath string, result *domain.Result) {
+	worktrees, err := a.client.Worktrees(ctx, repoPath)
+	if err != nil {
+		result.Details = append(result.Details, fmt.Sprintf("Could not list worktrees: %v", err))
+		return
+	}
+	if len(worktrees) <= 1 {
+		return
+	}
+
+	topLevel, _ := a.client.TopLevel(ctx, repoPath)
+	prunable := 0
+	for _, wt := range worktrees {
+		if wt.Bare {
+			continue
+		}
+		status := domain.WorktreeStatus{
+			Path:           wt.Path,
+			Branch:         wt.Branch,
+			Head:           wt.Head,
+			Current:        samePath(wt.Path, topLevel),
+			Detached:       wt.Detached,
+			Locked:         wt.Locked,
+			LockReason:     wt.LockReason,
+			Prunable:       wt.Prunable,
+			PrunableReason: wt.PrunableReason,
+		}
+		if wt.Prunable {
+			prunable++
+		} else if dirty, err := a.client.IsWorktreeDirty(ctx, wt.Path); err == nil {
+			status.Dirty = dirty
+		}
+		result.Worktrees = append(result.Worktrees, status)
+	}
+
+	result.Details = append(result.Details, fmt.Sprintf("Repository has %d worktrees", len(result.Worktrees)))
+	if prunable > 0 {
+		result.Details = append(result.Details, fmt.Sprintf("%d worktree(s) point to missing directories", prunable))
+		result.Actions = append(result.Actions, "Remove stale worktree metadata: git worktree prune")
+	}
+}
+
+func (a *Analyzer) branchesInOtherWorktrees(ctx context.Context, repoPath string) map[string]string {
+	worktrees, err := a.client.Worktrees(ctx, repoPath)
+	if err != nil || len(worktrees) <= 1 {
+		return nil
+	}
+	topLevel, _ := a.client.TopLevel(ctx, repoPath)
+
+	out := make(map[string]string, len(worktrees))
+	for _, wt := range worktrees {
+		if wt.Branch == "" || samePath(wt.Path, topLevel) {
+			continue
+		}
+		out[wt.Branch] = wt.Path
+	}
+	return out
+}
+
+func samePath(a string, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	return filepath.Clean(a) == filepath.Clean(b)
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/guionardo/git_sync_status/internal/gitclient"
+)
+
+func TestAnalyzerWorktrees(t *testing.T) {
+	t.Parallel()
+
+	fc := &fakeClient{
+		isRepo: true, currentBranch: "main", hasRemote: true, reachable: true,
+		upstream: "origin/main", topLevel: "/tmp/repo",
+		worktrees: []gitclient.Worktree{
+			{Path: "/tmp/repo", Branch: "main"},
+			{Path: "/tmp/repo-feature", Branch: "feature", Locked: true, LockReason: "usb disk"},
+			{Path: "/tmp/gone", Detached: true, Prunable: true},
+		},
+	}
+
+	got := NewAnalyzer(fc, "origin").Analyze(context.Background(), "/tmp/repo")
+	if len(got.Worktrees) != 3 {
+		t.Fatalf("got %d worktrees, want 3", len(got.Worktrees))
+	}
+	if !got.Worktrees[0].Current || got.Worktrees[1].Current {
+		t.Fatalf("unexpected current worktree: %+v", got.Worktrees)
+	}
+	if !got.Worktrees[1].Locked || got.Worktrees[1].LockReason != "usb disk" || !got.Worktrees[2].Prunable {
+		t.Fatalf("unexpected lock/prunable state: %+v", got.Worktrees)
+	}
+}
+
+func TestAnalyzeAllBranchesFlagsBranchesCheckedOutElsewhere(t *testing.T) {
+	t.Parallel()
+
+	fc := &fakeClient{
+		branches: []string{"main", "feature"},
+		upstream: "origin/x", topLevel: "/tmp/repo",
+		worktrees: []gitclient.Worktree{
+			{Path: "/tmp/repo", Branch: "main"},
+			{Path: "/tmp/repo-feature", Branch: "feature"},
+		},
+	}
+
+	rows, err := NewAnalyzer(fc, "origin").AnalyzeAllBranches(context.Background(), "/tmp/repo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if hasFlag(rows[0].Flags, "CHECKED_OUT_ELSEWHERE") {
+		t.Fatalf("current worktree branch must not be flagged: %+v", rows[0])
+	}
+	if !hasFlag(rows[1].Flags, "CHECKED_OUT_ELSEWHERE") || rows[1].Worktree != "/tmp/repo-feature" {
+		t.Fatalf("expected feature to be flagged: %+v", rows[1])
+	}
+}
@@ -0,0 +1,49 @@
+package tui
+
+import (
+	"sort"
+
+	"github.com/guionardo/git_sync_status/internal/service"
+)
+
+type branchSort int
+
+const (
+	sortByBranch branchSort = iota
+	sortByStatus
+	sortByLastCommit
+)
+
+func (s branchSort) next() branchSort {
+	return (s + 1) % 3
+}
+
+func (s branchSort) String() string {
+	switch s {
+	case sortByStatus:
+		return "status"
+	case sortByLastCommit:
+		return "last commit"
+	default:
+		return "branch"
+	}
+}
+
+func sortBranchRows(rows []service.BranchStatus, by branchSort) []service.BranchStatus {
+	sorted := make([]service.BranchStatus, len(rows))
+	copy(sorted, rows)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		switch by {
+		case sortByStatus:
+			if sorted[i].Status != sorted[j].Status {
+				return sorted[i].Status < sorted[j].Status
+			}
+		case sortByLastCommit:
+			if !sorted[i].LastCommitDate.Equal(sorted[j].LastCommitDate) {
+				return sorted[i].LastCommitDate.Before(sorted[j].LastCommitDate)
+			}
+		}
+		return sorted[i].Branch < sorted[j].Branch
+	})
+	return sorted
+}
@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+)
+
+var colorNames = map[string]string{
+	"black":   "0",
+	"gray":    "8",
+	"red":     "9",
+	"green":   "10",
+	"yellow":  "11",
+	"blue":    "12",
+	"magenta": "13",
+	"cyan":    "14",
+	"white":   "15",
+}
+
+func ParseFormat(text string) (*template.Template, error) {
+	return template.New("format").Funcs(formatFuncs()).Parse(text)
+}
+
+func ExecuteFormat(tmpl *template.Template, data any) (string, error) {
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func formatFuncs() template.FuncMap {
+	return template.FuncMap{
+		"color": func(name string, v any) string {
+			if code, ok := colorNames[strings.ToLower(name)]; ok {
+				name = code
+			}
+			return lipgloss.NewStyle().Foreground(lipgloss.Color(name)).Render(fmt.Sprint(v))
+		},
+		"bold": func(v any) string {
+			return titleStyle.Render(fmt.Sprint(v))
+		},
+		"status": func(status domain.Status) string {
+			return Model{}.renderStatusValue(status)
+		},
+		"padRight": func(width int, v any) string {
+			s := fmt.Sprint(v)
+			if pad := width - lipgloss.Width(s); pad > 0 {
+				return s + strings.Repeat(" ", pad)
+			}
+			return s
+		},
+		"padLeft": func(width int, v any) string {
+			s := fmt.Sprint(v)
+			if pad := width - lipgloss.Width(s); pad > 0 {
+				return strings.Repeat(" ", pad) + s
+			}
+			return s
+		},
+		"ago": func(t time.Time) string {
+			return relativeAge(t)
+		},
+		"join": func(sep string, items []string) string {
+			return strings.Join(items, sep)
+		},
+		"default": func(alt string, v any) string {
+			return fallback(fmt.Sprint(v), alt)
+		},
+	}
+}
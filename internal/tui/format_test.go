@@ -0,0 +1,60 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+	"github.com/guionardo/git_sync_status/internal/service"
+)
+
+func TestExecuteFormatResult(t *testing.T) {
+	t.Parallel()
+
+	tmpl, err := ParseFormat(`{{.Branch | padRight 8}}|{{.Ahead | padLeft 3}}|{{.Flags | join ","}}|{{default "-" .Upstream}}|{{if .HasFlag "WORKTREE_DIRTY"}}dirty{{end}}`)
+	if err != nil {
+		t.Fatalf("ParseFormat() error = %v", err)
+	}
+
+	got, err := ExecuteFormat(tmpl, domain.Result{Branch: "main", Ahead: 2, Flags: []string{"WORKTREE_DIRTY", "STALE"}})
+	if err != nil {
+		t.Fatalf("ExecuteFormat() error = %v", err)
+	}
+	if want := "main    |  2|WORKTREE_DIRTY,STALE|-|dirty"; got != want {
+		t.Fatalf("ExecuteFormat() = %q, want %q", got, want)
+	}
+}
+
+func TestExecuteFormatBranchStatus(t *testing.T) {
+	t.Parallel()
+
+	tmpl, err := ParseFormat(`{{.Branch}} {{ago .LastCommitDate}} {{status .Status}} {{color "red" .LastCommitAuthor}} {{bold "x"}}`)
+	if err != nil {
+		t.Fatalf("ParseFormat() error = %v", err)
+	}
+
+	got, err := ExecuteFormat(tmpl, service.BranchStatus{
+		Branch: "feature/x", Status: domain.StatusLate, LastCommitAuthor: "alice",
+		LastCommitDate: time.Now().Add(-3 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("ExecuteFormat() error = %v", err)
+	}
+	for _, want := range []string{"feature/x", "3h ago", "LATE", "alice", "x"} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("output missing %q. output: %s", want, got)
+		}
+	}
+}
+
+func TestParseFormatRejectsInvalidTemplate(t *testing.T) {
+	t.Parallel()
+
+	if _, err := ParseFormat("{{.Branch"); err == nil {
+		t.Fatal("expected parse error")
+	}
+	if _, err := ParseFormat("{{nope .Branch}}"); err == nil {
+		t.Fatal("expected error for unknown function")
+	}
+}
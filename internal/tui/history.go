@@ -0,0 +1,111 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/guionardo/git_sync_status/internal/history"
+)
+
+const historySparkWidth = 30
+
+var HistoryTableHeader = []string{"BRANCH", "STATUS", "FOR", "LAST SYNCED", "SAMPLES", "AHEAD", "BEHIND"}
+
+type HistorySource interface {
+	Summaries(repo string, branch string, since time.Time) ([]history.Summary, error)
+}
+
+func HistoryTableCells(s history.Summary, now time.Time) []string {
+	lastSynced := "never"
+	if days, ok := s.DaysSinceSynced(now); ok {
+		lastSynced = fmt.Sprintf("%dd ago", days)
+	}
+	return []string{
+		fallback(s.Branch, "-"),
+		string(s.Status),
+		shortDuration(s.StatusFor(now)),
+		lastSynced,
+		fmt.Sprintf("%d", s.Samples),
+		fmt.Sprintf("%s %d", Sparkline(s.AheadSeries, historySparkWidth), s.Ahead),
+		fmt.Sprintf("%s %d", Sparkline(s.BehindSeries, historySparkWidth), s.Behind),
+	}
+}
+
+func RenderHistoryTable(summaries []history.Summary, now time.Time) string {
+	if len(summaries) == 0 {
+		return "No history recorded."
+	}
+
+	widths := make([]int, len(HistoryTableHeader))
+	for i, title := range HistoryTableHeader {
+		widths[i] = len(title)
+	}
+	cells := make([][]string, 0, len(summaries))
+	for _, s := range summaries {
+		rowCells := HistoryTableCells(s, now)
+		for i, cell := range rowCells {
+			if n := utf8.RuneCountInString(cell); n > widths[i] {
+				widths[i] = n
+			}
+		}
+		cells = append(cells, rowCells)
+	}
+
+	total := 2 * (len(widths) - 1)
+	for _, w := range widths {
+		total += w
+	}
+
+	var b strings.Builder
+	writeRow := func(values []string) {
+		var row strings.Builder
+		for i, value := range values {
+			if i > 0 {
+				row.WriteString("  ")
+			}
+			row.WriteString(value)
+			row.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(value)))
+		}
+		b.WriteString(strings.TrimRight(row.String(), " "))
+		b.WriteString("\n")
+	}
+	writeRow(HistoryTableHeader)
+	fmt.Fprintf(&b, "%s\n", strings.Repeat("-", total))
+	for _, rowCells := range cells {
+		writeRow(rowCells)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func (m Model) renderHistoryLines(now time.Time) []string {
+	for _, s := range m.history {
+		if s.Branch != m.result.Branch {
+			continue
+		}
+		lines := []string{
+			"",
+			headerStyle.Render("History") + mutedStyle.Render(fmt.Sprintf(" (%d samples)", s.Samples)),
+			fmt.Sprintf("%s for %s", s.Status, shortDuration(s.StatusFor(now))),
+			"Ahead:  " + Sparkline(s.AheadSeries, historySparkWidth),
+			"Behind: " + Sparkline(s.BehindSeries, historySparkWidth),
+		}
+		if days, ok := s.DaysSinceSynced(now); ok {
+			lines = append(lines, fmt.Sprintf("Last synced: %dd ago", days))
+		}
+		return lines
+	}
+	return nil
+}
+
+func shortDuration(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
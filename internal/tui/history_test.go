@@ -0,0 +1,67 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+	"github.com/guionardo/git_sync_status/internal/history"
+)
+
+func TestRenderHistoryTable(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 4, 12, 0, 0, 0, time.UTC)
+	out := RenderHistoryTable([]history.Summary{{
+		Branch:       "main",
+		Status:       domain.StatusDiverged,
+		Ahead:        1,
+		Behind:       4,
+		StatusSince:  now.Add(-50 * time.Hour),
+		LastSynced:   now.Add(-72 * time.Hour),
+		Samples:      3,
+		AheadSeries:  []int{0, 1, 1},
+		BehindSeries: []int{0, 2, 4},
+	}}, now)
+
+	lines := strings.Split(out, "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines:\n%s", len(lines), out)
+	}
+	for _, want := range []string{"main", "DIVERGED", "2d", "3d ago", "▁██ 1", "▁▄█ 4"} {
+		if !strings.Contains(lines[2], want) {
+			t.Errorf("row %q missing %q", lines[2], want)
+		}
+	}
+	if RenderHistoryTable(nil, now) != "No history recorded." {
+		t.Error("empty table not reported")
+	}
+}
+
+func TestRenderHistoryLinesUsesFullHistory(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 4, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	m := Model{
+		result: domain.Result{Branch: "main", Status: domain.StatusDiverged},
+		history: []history.Summary{{
+			Branch:       "main",
+			Status:       domain.StatusDiverged,
+			StatusSince:  now.Add(-60 * day),
+			LastSynced:   now.Add(-70 * day),
+			FirstSeen:    now.Add(-30 * day),
+			Samples:      2,
+			AheadSeries:  []int{1, 1},
+			BehindSeries: []int{2, 4},
+		}},
+	}
+
+	out := strings.Join(m.renderHistoryLines(now), "\n")
+	for _, want := range []string{"DIVERGED for 60d", "Last synced: 70d ago"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("history lines missing %q:\n%s", want, out)
+		}
+	}
+}
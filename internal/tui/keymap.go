@@ -3,8 +3,10 @@ package tui
 import "github.com/charmbracelet/bubbles/key"
 
 type keyMap struct {
-	Refresh key.Binding
-	Quit    key.Binding
+	Refresh    key.Binding
+	Sort       key.Binding
+	Submodules key.Binding
+	Quit       key.Binding
 }
 
 func defaultKeyMap() keyMap {
@@ -13,6 +15,14 @@ func defaultKeyMap() keyMap {
 			key.WithKeys("r"),
 			key.WithHelp("r", "refresh"),
 		),
+		Sort: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "sort branches"),
+		),
+		Submodules: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "expand submodules"),
+		),
 		Quit: key.NewBinding(
 			key.WithKeys("q", "ctrl+c"),
 			key.WithHelp("q", "quit"),
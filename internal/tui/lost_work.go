@@ -0,0 +1,28 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/guionardo/git_sync_status/internal/service"
+)
+
+func RenderLostWorkAudit(audit service.LostWorkAudit) string {
+	if audit.TotalCommits == 0 {
+		return fmt.Sprintf("Every commit is reachable from a %s remote-tracking ref.", audit.Remote)
+	}
+
+	var out []string
+	out = append(out, fmt.Sprintf("%d commit(s) are not reachable from any remote-tracking ref:", audit.TotalCommits))
+	for _, group := range audit.Groups {
+		out = append(out, "", fmt.Sprintf("%s (%d)", group.Source, len(group.Commits)))
+		for _, c := range group.Commits {
+			date := "-"
+			if !c.Date.IsZero() {
+				date = c.Date.Format("2006-01-02")
+			}
+			out = append(out, fmt.Sprintf("  %.7s  %s  %s  %s", c.SHA, date, fallback(c.Author, "-"), c.Subject))
+		}
+	}
+	return strings.Join(out, "\n")
+}
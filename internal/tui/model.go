@@ -2,17 +2,21 @@ package tui
 
 import (
 	"context"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/guionardo/git_sync_status/internal/domain"
+	"github.com/guionardo/git_sync_status/internal/history"
 	"github.com/guionardo/git_sync_status/internal/service"
 )
 
 type resultMsg struct {
 	result     domain.Result
 	branchRows []service.BranchStatus
+	remoteRows []service.RemoteBranchStatus
+	history    []history.Summary
 	err        error
 }
 
@@ -20,15 +24,23 @@ type errMsg struct {
 	err error
 }
 
+const historyWindow = 30 * 24 * time.Hour
+
 type Model struct {
-	analyzer *service.Analyzer
-	repoPath string
-	keys     keyMap
+	analyzer      *service.Analyzer
+	repoPath      string
+	keys          keyMap
+	historySource HistorySource
 
 	loading  bool
 	result   domain.Result
 	branches []service.BranchStatus
+	remotes  []service.RemoteBranchStatus
+	history  []history.Summary
+	sortBy   branchSort
 	lastErr  error
+
+	showSubmodules bool
 }
 
 func NewModel(analyzer *service.Analyzer, repoPath string) Model {
@@ -40,6 +52,11 @@ func NewModel(analyzer *service.Analyzer, repoPath string) Model {
 	}
 }
 
+func (m Model) WithHistory(source HistorySource) Model {
+	m.historySource = source
+	return m
+}
+
 func (m Model) Init() tea.Cmd {
 	return m.refreshCmd()
 }
@@ -54,11 +71,19 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.loading = true
 			m.lastErr = nil
 			return m, m.refreshCmd()
+		case keyMatches(msg, m.keys.Sort):
+			m.sortBy = m.sortBy.next()
+			return m, nil
+		case keyMatches(msg, m.keys.Submodules):
+			m.showSubmodules = !m.showSubmodules
+			return m, nil
 		}
 	case resultMsg:
 		m.loading = false
 		m.result = msg.result
 		m.branches = msg.branchRows
+		m.remotes = msg.remoteRows
+		m.history = msg.history
 		m.lastErr = msg.err
 		return m, nil
 	case errMsg:
@@ -77,8 +102,16 @@ func (m Model) View() string {
 func (m Model) refreshCmd() tea.Cmd {
 	return func() tea.Msg {
 		result := m.analyzer.Analyze(context.Background(), m.repoPath)
+		var summaries []history.Summary
+		if m.historySource != nil {
+			summaries, _ = m.historySource.Summaries(history.RepoKey(m.repoPath), result.Branch, time.Now().Add(-historyWindow))
+		}
 		branchRows, err := m.analyzer.AnalyzeAllBranches(context.Background(), m.repoPath)
-		return resultMsg{result: result, branchRows: branchRows, err: err}
+		if err != nil {
+			return resultMsg{result: result, history: summaries, err: err}
+		}
+		remoteRows, err := m.analyzer.ScanRemoteOnlyBranches(context.Background(), m.repoPath)
+		return resultMsg{result: result, branchRows: branchRows, remoteRows: remoteRows, history: summaries, err: err}
 	}
 }
 
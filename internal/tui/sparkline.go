@@ -0,0 +1,34 @@
+package tui
+
+import "strings"
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+func Sparkline(values []int, width int) string {
+	if width > 0 && len(values) > width {
+		values = values[len(values)-width:]
+	}
+	if len(values) == 0 {
+		return ""
+	}
+
+	low, high := 0, 0
+	for _, v := range values {
+		if v < low {
+			low = v
+		}
+		if v > high {
+			high = v
+		}
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		idx := 0
+		if high > low {
+			idx = (v - low) * (len(sparkBlocks) - 1) / (high - low)
+		}
+		b.WriteRune(sparkBlocks[idx])
+	}
+	return b.String()
+}
@@ -0,0 +1,24 @@
+package tui
+
+import "testing"
+
+func TestSparkline(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		values []int
+		width  int
+		want   string
+	}{
+		{nil, 10, ""},
+		{[]int{0, 0, 0}, 10, "▁▁▁"},
+		{[]int{0, 1, 2, 3, 4, 5, 6, 7}, 0, "▁▂▃▄▅▆▇█"},
+		{[]int{7, 7}, 0, "██"},
+		{[]int{9, 0, 14, 7}, 2, "█▄"},
+	}
+	for _, tt := range tests {
+		if got := Sparkline(tt.values, tt.width); got != tt.want {
+			t.Errorf("Sparkline(%v, %d) = %q, want %q", tt.values, tt.width, got, tt.want)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package tui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/guionardo/git_sync_status/internal/domain"
 	"github.com/guionardo/git_sync_status/internal/service"
@@ -32,9 +33,13 @@ func (m Model) renderView() string {
 		b.WriteString("\n\n")
 		b.WriteString(boxStyle.Render(m.renderAllBranchesCard()))
 	}
+	if len(m.remotes) > 0 {
+		b.WriteString("\n\n")
+		b.WriteString(boxStyle.Render(m.renderRemoteBranchesCard()))
+	}
 	b.WriteString("\n\n")
 
-	help := []string{"r refresh", "q quit"}
+	help := []string{"r refresh", "s sort branches", "m submodules", "q quit"}
 	b.WriteString(mutedStyle.Render(strings.Join(help, " • ")))
 	b.WriteString("\n")
 
@@ -61,6 +66,89 @@ func (m Model) renderStatusCard() string {
 		}
 	}
 
+	lines = append(lines, m.renderHistoryLines(time.Now())...)
+
+	if r.Detached != nil {
+		lines = append(lines, "", headerStyle.Render("Detached HEAD"))
+		lines = append(lines, fmt.Sprintf("Commit: %.7s", r.Detached.Commit))
+		lines = append(lines, "Local branches: "+fallback(strings.Join(r.Detached.LocalBranches, ", "), "-"))
+		lines = append(lines, "Remote branches: "+fallback(strings.Join(r.Detached.RemoteBranches, ", "), "-"))
+		lines = append(lines, "Tags: "+fallback(strings.Join(r.Detached.Tags, ", "), "-"))
+		if r.Detached.OnRemote {
+			lines = append(lines, okStyle.Render("Reachable from a remote branch"))
+		} else {
+			lines = append(lines, errStyle.Render("Not reachable from any remote branch"))
+		}
+	}
+
+	if len(r.Submodules) > 0 {
+		lines = append(lines, "")
+		if m.showSubmodules {
+			lines = append(lines, headerStyle.Render(fmt.Sprintf("Submodules (%d)", len(r.Submodules))))
+			lines = append(lines, renderSubmoduleTree(r.Submodules, 0)...)
+		} else {
+			lines = append(lines, headerStyle.Render(fmt.Sprintf("Submodules (%d)", len(r.Submodules)))+mutedStyle.Render(" press m to expand"))
+		}
+	}
+
+	if len(r.Findings) > 0 {
+		lines = append(lines, "", errStyle.Render("Risky outgoing changes"))
+		for _, f := range r.Findings {
+			lines = append(lines, fmt.Sprintf("- %s %s (%.7s): %s", f.Rule, f.Path, f.Commit, f.Detail))
+		}
+	}
+
+	if r.Policy != nil && (len(r.Policy.UnsignedCommits) > 0 || len(r.Policy.ForeignAuthorCommits) > 0) {
+		lines = append(lines, "", errStyle.Render("Commit policy"))
+		for _, sha := range r.Policy.UnsignedCommits {
+			lines = append(lines, fmt.Sprintf("- %.7s unsigned or invalid signature", sha))
+		}
+		for _, sha := range r.Policy.ForeignAuthorCommits {
+			lines = append(lines, fmt.Sprintf("- %.7s author/committer email outside policy", sha))
+		}
+	}
+
+	if r.Tags != nil && (len(r.Tags.LocalOnly) > 0 || len(r.Tags.RemoteOnly) > 0 || len(r.Tags.Mismatched) > 0) {
+		lines = append(lines, "", headerStyle.Render("Tags"))
+		if len(r.Tags.LocalOnly) > 0 {
+			lines = append(lines, "Not pushed: "+strings.Join(r.Tags.LocalOnly, ", "))
+		}
+		if len(r.Tags.RemoteOnly) > 0 {
+			lines = append(lines, "Missing locally: "+strings.Join(r.Tags.RemoteOnly, ", "))
+		}
+		for _, mm := range r.Tags.Mismatched {
+			lines = append(lines, errStyle.Render(fmt.Sprintf("Differs: %s (local %.7s, remote %.7s)", mm.Name, mm.LocalCommit, mm.RemoteCommit)))
+		}
+	}
+
+	if r.LFS != nil {
+		lines = append(lines, "", headerStyle.Render("Git LFS"))
+		lines = append(lines, fmt.Sprintf("Tracked files: %d, local objects: %d", r.LFS.TrackedFiles, r.LFS.LocalObjects))
+		if r.LFS.Unknown {
+			lines = append(lines, mutedStyle.Render("Unpushed objects: unknown"))
+		}
+		if r.LFS.UnpushedObjects > 0 {
+			lines = append(lines, warnStyle.Render(fmt.Sprintf("Unpushed objects: %d (%d bytes)", r.LFS.UnpushedObjects, r.LFS.UnpushedBytes)))
+			for _, path := range r.LFS.UnpushedPaths {
+				lines = append(lines, "- "+path)
+			}
+		}
+	}
+
+	if len(r.Worktrees) > 0 {
+		lines = append(lines, "", headerStyle.Render("Worktrees"))
+		for _, wt := range r.Worktrees {
+			lines = append(lines, "- "+renderWorktree(wt))
+		}
+	}
+
+	if len(r.Conflicts) > 0 {
+		lines = append(lines, "", errStyle.Render("Predicted conflicts"))
+		for _, c := range r.Conflicts {
+			lines = append(lines, fmt.Sprintf("- %s: %s", c.Against, strings.Join(c.Files, ", ")))
+		}
+	}
+
 	if len(r.Actions) > 0 {
 		lines = append(lines, "", headerStyle.Render("Suggested actions"))
 		for _, action := range r.Actions {
@@ -82,13 +170,64 @@ func (m Model) renderStatusCard() string {
 	return strings.Join(lines, "\n")
 }
 
+func renderWorktree(wt domain.WorktreeStatus) string {
+	ref := wt.Branch
+	if wt.Detached || ref == "" {
+		ref = "(detached)"
+	}
+	var state []string
+	if wt.Current {
+		state = append(state, "current")
+	}
+	if wt.Dirty {
+		state = append(state, "dirty")
+	}
+	if wt.Locked {
+		state = append(state, strings.TrimSpace("locked "+wt.LockReason))
+	}
+	if wt.Prunable {
+		state = append(state, "prunable")
+	}
+	if len(state) == 0 {
+		return fmt.Sprintf("%s [%s]", wt.Path, ref)
+	}
+	return fmt.Sprintf("%s [%s] %s", wt.Path, ref, strings.Join(state, ", "))
+}
+
+func renderSubmoduleTree(submodules []domain.SubmoduleStatus, depth int) []string {
+	indent := strings.Repeat("  ", depth)
+	var lines []string
+	for _, sm := range submodules {
+		var state []string
+		switch {
+		case !sm.Initialized:
+			state = append(state, "not initialized")
+		case !sm.AtRecordedCommit:
+			state = append(state, "not at recorded commit")
+		}
+		if sm.Dirty {
+			state = append(state, "local changes")
+		}
+		if sm.Initialized {
+			state = append(state, fmt.Sprintf("%s %d/%d", fallback(string(sm.Status), "-"), sm.Ahead, sm.Behind))
+		}
+		marker := okStyle.Render("✓")
+		if !sm.InSync() {
+			marker = warnStyle.Render("!")
+		}
+		lines = append(lines, fmt.Sprintf("%s%s %s (%s)", indent, marker, sm.Path, strings.Join(state, ", ")))
+		lines = append(lines, renderSubmoduleTree(sm.Submodules, depth+1)...)
+	}
+	return lines
+}
+
 func (m Model) renderStatusValue(status domain.Status) string {
-	switch status {
-	case domain.StatusSynced:
+	switch status.Severity() {
+	case domain.SeverityOK:
 		return okStyle.Render(string(status))
-	case domain.StatusSyncPending, domain.StatusLate, domain.StatusNoUpstream:
+	case domain.SeverityWarn:
 		return warnStyle.Render(string(status))
-	case domain.StatusDiverged, domain.StatusNoRemote, domain.StatusNotAGitRepo:
+	case domain.SeverityError:
 		return errStyle.Render(string(status))
 	default:
 		return string(status)
@@ -97,65 +236,225 @@ func (m Model) renderStatusValue(status domain.Status) string {
 
 func (m Model) renderAllBranchesCard() string {
 	lines := []string{
-		headerStyle.Render("All Branches"),
+		headerStyle.Render("All Branches") + mutedStyle.Render(" (sorted by "+m.sortBy.String()+")"),
 		"",
-		m.renderBranchTable(m.branches),
+		m.renderBranchTable(sortBranchRows(m.branches, m.sortBy)),
+	}
+
+	var suggestions []string
+	for _, row := range m.branches {
+		if row.Suggestion != "" {
+			suggestions = append(suggestions, "- "+row.Suggestion)
+		}
+		if row.Worktree != "" {
+			suggestions = append(suggestions, fmt.Sprintf("- %s is checked out in worktree %s; checkout and deletion are blocked there", row.Branch, row.Worktree))
+		}
+		for _, c := range row.Conflicts {
+			suggestions = append(suggestions, fmt.Sprintf("- %s will conflict with %s: %s", row.Branch, c.Against, strings.Join(c.Files, ", ")))
+		}
+	}
+	if len(suggestions) > 0 {
+		lines = append(lines, "", headerStyle.Render("Branch suggestions"))
+		lines = append(lines, suggestions...)
 	}
 	return strings.Join(lines, "\n")
 }
 
 func (m Model) renderBranchTable(rows []service.BranchStatus) string {
+	return RenderBranchTable(rows)
+}
+
+var BranchTableHeader = []string{"BRANCH", "UPSTREAM", "STATUS", "A/B", "VS DEFAULT", "LAST COMMIT", "CREATED", "AUTHOR", "FLAGS"}
+
+func BranchTableCells(row service.BranchStatus) []string {
+	flags := "-"
+	if len(row.Flags) > 0 {
+		flags = strings.Join(row.Flags, ",")
+	}
+	created := relativeAge(row.CreatedAt)
+	if row.CreatedApprox {
+		created = "~" + created
+	}
+	return []string{
+		row.Branch,
+		fallback(row.Upstream, "-"),
+		string(row.Status),
+		fmt.Sprintf("%d/%d", row.Ahead, row.Behind),
+		defaultAheadBehind(row),
+		relativeAge(row.LastCommitDate),
+		created,
+		fallback(row.LastCommitAuthor, "-"),
+		flags,
+	}
+}
+
+func RenderBranchTable(rows []service.BranchStatus) string {
+	widths := make([]int, len(BranchTableHeader))
+	for i, title := range BranchTableHeader {
+		widths[i] = len(title)
+	}
+	cells := make([][]string, 0, len(rows))
+	for _, row := range rows {
+		rowCells := BranchTableCells(row)
+		for i, cell := range rowCells {
+			if len(cell) > widths[i] {
+				widths[i] = len(cell)
+			}
+		}
+		cells = append(cells, rowCells)
+	}
+
+	total := 2 * (len(widths) - 1)
+	for _, w := range widths {
+		total += w
+	}
+
+	var b strings.Builder
+	writeRow := func(values []string) {
+		for i, value := range values {
+			if i > 0 {
+				b.WriteString("  ")
+			}
+			fmt.Fprintf(&b, "%-*s", widths[i], value)
+		}
+		b.WriteString("\n")
+	}
+	writeRow(BranchTableHeader)
+	fmt.Fprintf(&b, "%s\n", strings.Repeat("-", total))
+	for _, rowCells := range cells {
+		writeRow(rowCells)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func (m Model) renderRemoteBranchesCard() string {
+	lines := []string{
+		headerStyle.Render("Remote-only Branches"),
+		"",
+		RenderRemoteBranchTable(m.remotes),
+	}
+	return strings.Join(lines, "\n")
+}
+
+func RenderRemoteBranchTable(rows []service.RemoteBranchStatus) string {
+	if len(rows) == 0 {
+		return "No remote-only branches found."
+	}
+
 	branchW := len("BRANCH")
-	upstreamW := len("UPSTREAM")
-	statusW := len("STATUS")
-	abW := len("A/B")
-	flagsW := len("FLAGS")
+	authorW := len("AUTHOR")
+	dateW := len("LAST COMMIT")
+	mergedW := len("MERGED")
 
 	for _, row := range rows {
 		if len(row.Branch) > branchW {
 			branchW = len(row.Branch)
 		}
-		up := fallback(row.Upstream, "-")
-		if len(up) > upstreamW {
-			upstreamW = len(up)
+		if len(row.LastCommitAuthor) > authorW {
+			authorW = len(row.LastCommitAuthor)
 		}
-		if len(string(row.Status)) > statusW {
-			statusW = len(string(row.Status))
+		if len(remoteMergedLabel(row)) > mergedW {
+			mergedW = len(remoteMergedLabel(row))
 		}
-		ab := fmt.Sprintf("%d/%d", row.Ahead, row.Behind)
-		if len(ab) > abW {
-			abW = len(ab)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-*s  %-*s  %-*s  %-*s\n",
+		branchW, "BRANCH", authorW, "AUTHOR", dateW, "LAST COMMIT", mergedW, "MERGED")
+	fmt.Fprintf(&b, "%s\n", strings.Repeat("-", branchW+authorW+dateW+mergedW+6))
+	for _, row := range rows {
+		date := "-"
+		if !row.LastCommitDate.IsZero() {
+			date = row.LastCommitDate.Format("2006-01-02")
 		}
-		flags := "-"
-		if len(row.Flags) > 0 {
-			flags = strings.Join(row.Flags, ",")
+		fmt.Fprintf(&b, "%-*s  %-*s  %-*s  %-*s\n",
+			branchW, row.Branch,
+			authorW, fallback(row.LastCommitAuthor, "-"),
+			dateW, date,
+			mergedW, remoteMergedLabel(row),
+		)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func RenderRepositoryTable(results []domain.Result) string {
+	if len(results) == 0 {
+		return "No repositories found."
+	}
+
+	pathW := len("REPOSITORY")
+	branchW := len("BRANCH")
+	statusW := len("STATUS")
+	abW := len("A/B")
+
+	for _, result := range results {
+		if len(result.RepoPath) > pathW {
+			pathW = len(result.RepoPath)
+		}
+		if len(result.Branch) > branchW {
+			branchW = len(result.Branch)
+		}
+		if len(result.Status) > statusW {
+			statusW = len(result.Status)
 		}
-		if len(flags) > flagsW {
-			flagsW = len(flags)
+		if ab := fmt.Sprintf("%d/%d", result.Ahead, result.Behind); len(ab) > abW {
+			abW = len(ab)
 		}
 	}
 
 	var b strings.Builder
-	fmt.Fprintf(&b, "%-*s  %-*s  %-*s  %-*s  %-*s\n",
-		branchW, "BRANCH", upstreamW, "UPSTREAM", statusW, "STATUS", abW, "A/B", flagsW, "FLAGS")
-	fmt.Fprintf(&b, "%s\n", strings.Repeat("-", branchW+upstreamW+statusW+abW+flagsW+8))
-	for _, row := range rows {
+	fmt.Fprintf(&b, "%-*s  %-*s  %-*s  %-*s  %s\n",
+		pathW, "REPOSITORY", branchW, "BRANCH", statusW, "STATUS", abW, "A/B", "FLAGS")
+	fmt.Fprintf(&b, "%s\n", strings.Repeat("-", pathW+branchW+statusW+abW+13))
+	for _, result := range results {
 		flags := "-"
-		if len(row.Flags) > 0 {
-			flags = strings.Join(row.Flags, ",")
+		if len(result.Flags) > 0 {
+			flags = strings.Join(result.Flags, ",")
 		}
-		ab := fmt.Sprintf("%d/%d", row.Ahead, row.Behind)
-		fmt.Fprintf(&b, "%-*s  %-*s  %-*s  %-*s  %-*s\n",
-			branchW, row.Branch,
-			upstreamW, fallback(row.Upstream, "-"),
-			statusW, string(row.Status),
-			abW, ab,
-			flagsW, flags,
+		fmt.Fprintf(&b, "%-*s  %-*s  %-*s  %-*s  %s\n",
+			pathW, result.RepoPath,
+			branchW, fallback(result.Branch, "-"),
+			statusW, result.Status,
+			abW, fmt.Sprintf("%d/%d", result.Ahead, result.Behind),
+			flags,
 		)
 	}
 	return strings.TrimRight(b.String(), "\n")
 }
 
+func remoteMergedLabel(row service.RemoteBranchStatus) string {
+	switch {
+	case row.MergedInto == "":
+		return "-"
+	case row.Merged:
+		return "yes (" + row.MergedInto + ")"
+	default:
+		return "no"
+	}
+}
+
+func defaultAheadBehind(row service.BranchStatus) string {
+	if row.DefaultBranch == "" {
+		return "-"
+	}
+	return fmt.Sprintf("%d/%d", row.DefaultAhead, row.DefaultBehind)
+}
+
+func relativeAge(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	d := time.Since(t)
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
+
 func fallback(value string, alt string) string {
 	if strings.TrimSpace(value) == "" {
 		return alt
@@ -3,6 +3,7 @@ package tui
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/guionardo/git_sync_status/internal/domain"
 	"github.com/guionardo/git_sync_status/internal/service"
@@ -34,6 +35,34 @@ func TestRenderStatusCard(t *testing.T) {
 	}
 }
 
+func TestRenderStatusCardConflicts(t *testing.T) {
+	t.Parallel()
+
+	m := Model{
+		repoPath: "/tmp/repo",
+		result: domain.Result{
+			RepoPath: "/tmp/repo",
+			Branch:   "main",
+			Upstream: "origin/main",
+			Status:   domain.StatusDiverged,
+			Ahead:    1,
+			Behind:   2,
+			Flags:    []string{"WILL_CONFLICT"},
+			Conflicts: []domain.ConflictPrediction{
+				{Against: "origin/main", Files: []string{"go.mod", "main.go"}},
+			},
+		},
+	}
+
+	out := m.renderStatusCard()
+	wantContains := []string{"WILL_CONFLICT", "Predicted conflicts", "origin/main: go.mod, main.go"}
+	for _, want := range wantContains {
+		if !strings.Contains(out, want) {
+			t.Fatalf("output missing %q. output: %s", want, out)
+		}
+	}
+}
+
 func TestRenderBranchTable(t *testing.T) {
 	t.Parallel()
 
@@ -66,3 +95,270 @@ func TestRenderBranchTable(t *testing.T) {
 		}
 	}
 }
+
+func TestRenderRemoteBranchTable(t *testing.T) {
+	t.Parallel()
+
+	out := RenderRemoteBranchTable([]service.RemoteBranchStatus{
+		{Branch: "origin/feature/x", LastCommitAuthor: "bob", LastCommitDate: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), MergedInto: "origin/main"},
+		{Branch: "origin/old", LastCommitAuthor: "carol", MergedInto: "origin/main", Merged: true},
+	})
+	wantContains := []string{"BRANCH", "AUTHOR", "LAST COMMIT", "MERGED", "origin/feature/x", "bob", "2024-05-01", "yes (origin/main)"}
+	for _, want := range wantContains {
+		if !strings.Contains(out, want) {
+			t.Fatalf("output missing %q. output: %s", want, out)
+		}
+	}
+}
+
+func TestRenderRepositoryTable(t *testing.T) {
+	t.Parallel()
+
+	out := RenderRepositoryTable([]domain.Result{
+		{RepoPath: "/src/api", Branch: "main", Status: domain.StatusDiverged, Ahead: 2, Behind: 3, Flags: []string{"WORKTREE_DIRTY"}},
+		{RepoPath: "/src/scratch", Status: domain.StatusNoRemote},
+	})
+	wantContains := []string{"REPOSITORY", "STATUS", "/src/api", "DIVERGED", "2/3", "WORKTREE_DIRTY", "/src/scratch", "NO_REMOTE"}
+	for _, want := range wantContains {
+		if !strings.Contains(out, want) {
+			t.Fatalf("output missing %q. output: %s", want, out)
+		}
+	}
+}
+
+func TestRenderBranchTableSortedByLastCommit(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	m := Model{
+		sortBy: sortByLastCommit,
+		branches: []service.BranchStatus{
+			{Branch: "a-recent", Status: domain.StatusSynced, LastCommitDate: now.Add(-2 * time.Hour), CreatedAt: now.Add(-3 * 24 * time.Hour), LastCommitAuthor: "alice"},
+			{Branch: "z-old", Status: domain.StatusNoUpstream, LastCommitDate: now.Add(-120 * 24 * time.Hour), CreatedAt: now.Add(-200 * 24 * time.Hour), CreatedApprox: true, Stale: true, Flags: []string{"STALE"}},
+		},
+	}
+
+	out := m.renderAllBranchesCard()
+	for _, want := range []string{"sorted by last commit", "LAST COMMIT", "CREATED", "AUTHOR", "2h ago", "3d ago", "120d ago", "~200d ago", "alice", "STALE"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("output missing %q. output: %s", want, out)
+		}
+	}
+	if strings.Index(out, "z-old") > strings.Index(out, "a-recent") {
+		t.Fatalf("expected oldest branch first. output: %s", out)
+	}
+}
+
+func TestRenderBranchTableDefaultBranchComparison(t *testing.T) {
+	t.Parallel()
+
+	m := Model{
+		branches: []service.BranchStatus{
+			{
+				Branch: "feature/x", Status: domain.StatusSynced,
+				DefaultBranch: "origin/main", DefaultAhead: 2, DefaultBehind: 40,
+				Suggestion: "feature/x is 40 commits behind origin/main; rebase before opening a PR: git rebase origin/main",
+			},
+		},
+	}
+
+	out := m.renderAllBranchesCard()
+	for _, want := range []string{"VS DEFAULT", "2/40", "Branch suggestions", "40 commits behind origin/main"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("output missing %q. output: %s", want, out)
+		}
+	}
+}
+
+func TestRenderStatusCardSubmodules(t *testing.T) {
+	t.Parallel()
+
+	m := Model{
+		result: domain.Result{
+			RepoPath: "/tmp/repo",
+			Status:   domain.StatusSynced,
+			Submodules: []domain.SubmoduleStatus{
+				{
+					Path: "libs/a", Initialized: true, AtRecordedCommit: true, Status: domain.StatusSynced,
+					Submodules: []domain.SubmoduleStatus{{Path: "nested", Initialized: true, Status: domain.StatusLate, Behind: 1}},
+				},
+				{Path: "libs/b"},
+			},
+		},
+	}
+
+	collapsed := m.renderStatusCard()
+	if !strings.Contains(collapsed, "Submodules (2)") || strings.Contains(collapsed, "libs/a") {
+		t.Fatalf("expected collapsed submodule section. output: %s", collapsed)
+	}
+
+	m.showSubmodules = true
+	expanded := m.renderStatusCard()
+	for _, want := range []string{"libs/a (SYNCED 0/0)", "  ! nested (not at recorded commit, LATE 0/1)", "libs/b (not initialized)"} {
+		if !strings.Contains(expanded, want) {
+			t.Fatalf("output missing %q. output: %s", want, expanded)
+		}
+	}
+}
+
+func TestRenderStatusCardWorktrees(t *testing.T) {
+	t.Parallel()
+
+	m := Model{
+		result: domain.Result{
+			RepoPath: "/tmp/repo",
+			Status:   domain.StatusSynced,
+			Worktrees: []domain.WorktreeStatus{
+				{Path: "/tmp/repo", Branch: "main", Current: true},
+				{Path: "/tmp/repo-feature", Branch: "feature", Dirty: true, Locked: true, LockReason: "usb disk"},
+				{Path: "/tmp/gone", Detached: true, Prunable: true},
+			},
+		},
+	}
+
+	out := m.renderStatusCard()
+	for _, want := range []string{"Worktrees", "/tmp/repo [main] current", "/tmp/repo-feature [feature] dirty, locked usb disk", "/tmp/gone [(detached)] prunable"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("output missing %q. output: %s", want, out)
+		}
+	}
+}
+
+func TestRenderStatusCardLFS(t *testing.T) {
+	t.Parallel()
+
+	m := Model{
+		result: domain.Result{
+			RepoPath: "/tmp/repo",
+			Status:   domain.StatusSynced,
+			Flags:    []string{"LFS_UNPUSHED"},
+			LFS: &domain.LFSStatus{
+				TrackedFiles: 3, LocalObjects: 2, UnpushedObjects: 1, UnpushedBytes: 2048,
+				UnpushedPaths: []string{"assets/big.bin"},
+			},
+		},
+	}
+
+	out := m.renderStatusCard()
+	for _, want := range []string{"Git LFS", "Tracked files: 3, local objects: 2", "Unpushed objects: 1 (2048 bytes)", "assets/big.bin"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("output missing %q. output: %s", want, out)
+		}
+	}
+}
+
+func TestRenderStatusCardTags(t *testing.T) {
+	t.Parallel()
+
+	m := Model{
+		result: domain.Result{
+			RepoPath: "/tmp/repo",
+			Status:   domain.StatusSynced,
+			Tags: &domain.TagStatus{
+				LocalOnly:  []string{"v2.0.0"},
+				RemoteOnly: []string{"v0.9.0"},
+				Mismatched: []domain.TagMismatch{{Name: "v1.1.0", LocalCommit: "1234567890", RemoteCommit: "abcdef1234"}},
+			},
+		},
+	}
+
+	out := m.renderStatusCard()
+	for _, want := range []string{"Tags", "Not pushed: v2.0.0", "Missing locally: v0.9.0", "Differs: v1.1.0 (local 1234567, remote abcdef1)"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("output missing %q. output: %s", want, out)
+		}
+	}
+}
+
+func TestRenderLostWorkAudit(t *testing.T) {
+	t.Parallel()
+
+	empty := RenderLostWorkAudit(service.LostWorkAudit{Remote: "origin"})
+	if !strings.Contains(empty, "Every commit is reachable") {
+		t.Fatalf("unexpected empty audit output: %s", empty)
+	}
+
+	out := RenderLostWorkAudit(service.LostWorkAudit{
+		Remote:       "origin",
+		TotalCommits: 2,
+		Groups: []service.LostWorkGroup{
+			{Source: "feature", Commits: []service.LostCommit{{SHA: "0123456789abcdef", Author: "alice", Subject: "wip"}}},
+			{Source: "stash", Commits: []service.LostCommit{{SHA: "fedcba9876543210", Subject: "WIP on main"}}},
+		},
+	})
+	for _, want := range []string{"2 commit(s)", "feature (1)", "0123456", "alice", "wip", "stash (1)", "WIP on main"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("output missing %q. output: %s", want, out)
+		}
+	}
+}
+
+func TestRenderStatusCardDetachedHead(t *testing.T) {
+	t.Parallel()
+
+	m := Model{
+		result: domain.Result{
+			RepoPath: "/tmp/repo",
+			Branch:   "(detached)",
+			Status:   domain.StatusDetached,
+			Detached: &domain.DetachedHeadStatus{
+				Commit:        "0123456789abcdef",
+				LocalBranches: []string{"feature"},
+				Tags:          []string{"v1.0.0"},
+			},
+		},
+	}
+
+	out := m.renderStatusCard()
+	for _, want := range []string{"DETACHED", "Commit: 0123456", "Local branches: feature", "Remote branches: -", "Tags: v1.0.0", "Not reachable from any remote branch"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("output missing %q. output: %s", want, out)
+		}
+	}
+}
+
+func TestRenderStatusCardCommitPolicy(t *testing.T) {
+	t.Parallel()
+
+	m := Model{
+		result: domain.Result{
+			RepoPath: "/tmp/repo",
+			Status:   domain.StatusSyncPending,
+			Flags:    []string{"UNSIGNED_COMMITS", "FOREIGN_AUTHOR"},
+			Policy: &domain.PolicyStatus{
+				CheckedCommits:       2,
+				UnsignedCommits:      []string{"0123456789"},
+				ForeignAuthorCommits: []string{"abcdef0123"},
+			},
+		},
+	}
+
+	out := m.renderStatusCard()
+	for _, want := range []string{"Commit policy", "0123456 unsigned", "abcdef0 author/committer email outside policy"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("output missing %q. output: %s", want, out)
+		}
+	}
+}
+
+func TestRenderStatusCardFindings(t *testing.T) {
+	t.Parallel()
+
+	m := Model{
+		result: domain.Result{
+			RepoPath: "/tmp/repo",
+			Status:   domain.StatusSyncPending,
+			Flags:    []string{"RISKY_PUSH"},
+			Findings: []domain.RiskFinding{
+				{Rule: "ENV_FILE", Commit: "0123456789", Path: ".env", Detail: "environment files usually contain credentials"},
+			},
+		},
+	}
+
+	out := m.renderStatusCard()
+	for _, want := range []string{"Risky outgoing changes", "ENV_FILE .env (0123456)"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("output missing %q. output: %s", want, out)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package watch
+
+import (
+	"context"
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+	"github.com/guionardo/git_sync_status/internal/notify"
+)
+
+type Analyzer interface {
+	Analyze(ctx context.Context, repoPath string) domain.Result
+}
+
+type Watcher struct {
+	OnEvent func(event notify.Event, err error)
+
+	analyzer Analyzer
+	notifier notify.Notifier
+	repos    []string
+	interval time.Duration
+	now      func() time.Time
+	last     map[string]domain.Result
+}
+
+func NewWatcher(analyzer Analyzer, notifier notify.Notifier, repos []string, interval time.Duration) *Watcher {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &Watcher{
+		analyzer: analyzer,
+		notifier: notifier,
+		repos:    repos,
+		interval: interval,
+		now:      time.Now,
+		last:     map[string]domain.Result{},
+	}
+}
+
+func (w *Watcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		w.Poll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *Watcher) Poll(ctx context.Context) []notify.Event {
+	var events []notify.Event
+	for _, repo := range w.repos {
+		if ctx.Err() != nil {
+			break
+		}
+		current := w.analyzer.Analyze(ctx, repo)
+		previous, seen := w.last[repo]
+		w.last[repo] = current
+		if !seen {
+			continue
+		}
+		for _, event := range Transitions(repo, previous, current, w.now()) {
+			var err error
+			if w.notifier != nil {
+				err = w.notifier.Notify(ctx, event)
+			}
+			if w.OnEvent != nil {
+				w.OnEvent(event, err)
+			}
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+func Transitions(repo string, previous domain.Result, current domain.Result, at time.Time) []notify.Event {
+	base := notify.Event{
+		Repo:   repo,
+		Branch: current.Branch,
+		From:   previous.Status,
+		To:     current.Status,
+		Result: current,
+		At:     at,
+	}
+
+	var events []notify.Event
+	if previous.Status != current.Status || previous.Branch != current.Branch {
+		event := base
+		event.Reason = notify.ReasonStatus
+		events = append(events, event)
+	}
+
+	wasFailing := previous.HasFlag("REMOTE_UNREACHABLE")
+	isFailing := current.HasFlag("REMOTE_UNREACHABLE")
+	switch {
+	case isFailing && !wasFailing:
+		event := base
+		event.Reason = notify.ReasonFetchFailing
+		events = append(events, event)
+	case wasFailing && !isFailing:
+		event := base
+		event.Reason = notify.ReasonFetchRecovered
+		events = append(events, event)
+	}
+	return events
+}
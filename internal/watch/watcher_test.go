@@ -0,0 +1,71 @@
+package watch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+	"github.com/guionardo/git_sync_status/internal/notify"
+)
+
+type fakeAnalyzer map[string]domain.Result
+
+func (f fakeAnalyzer) Analyze(_ context.Context, repoPath string) domain.Result {
+	return f[repoPath]
+}
+
+type fakeNotifier struct {
+	events []notify.Event
+}
+
+func (f *fakeNotifier) Notify(_ context.Context, event notify.Event) error {
+	f.events = append(f.events, event)
+	return nil
+}
+
+func TestWatcherNotifiesOnTransitions(t *testing.T) {
+	t.Parallel()
+
+	analyzer := fakeAnalyzer{
+		"/a": {RepoPath: "/a", Branch: "main", Status: domain.StatusSynced},
+		"/b": {RepoPath: "/b", Branch: "main", Status: domain.StatusSynced},
+	}
+	fake := &fakeNotifier{}
+	watcher := NewWatcher(analyzer, fake, []string{"/a", "/b"}, time.Minute)
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	watcher.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	if events := watcher.Poll(ctx); len(events) != 0 {
+		t.Fatalf("baseline poll emitted %d events", len(events))
+	}
+
+	analyzer["/a"] = domain.Result{RepoPath: "/a", Branch: "main", Status: domain.StatusLate, Behind: 3}
+	analyzer["/b"] = domain.Result{RepoPath: "/b", Branch: "main", Status: domain.StatusSynced, Flags: []string{"REMOTE_UNREACHABLE"}}
+	watcher.Poll(ctx)
+
+	if len(fake.events) != 2 {
+		t.Fatalf("got %d events, want 2: %+v", len(fake.events), fake.events)
+	}
+	late := fake.events[0]
+	if late.Repo != "/a" || late.Reason != notify.ReasonStatus || late.From != domain.StatusSynced || late.To != domain.StatusLate || !late.At.Equal(now) {
+		t.Fatalf("unexpected status event: %+v", late)
+	}
+	if got := late.Title(); got != "/a: SYNCED → LATE" {
+		t.Fatalf("Title() = %q", got)
+	}
+	if failing := fake.events[1]; failing.Repo != "/b" || failing.Reason != notify.ReasonFetchFailing || !failing.Urgent() {
+		t.Fatalf("unexpected fetch event: %+v", failing)
+	}
+
+	if events := watcher.Poll(ctx); len(events) != 0 {
+		t.Fatalf("unchanged poll emitted %d events", len(events))
+	}
+
+	analyzer["/b"] = domain.Result{RepoPath: "/b", Branch: "main", Status: domain.StatusSynced}
+	events := watcher.Poll(ctx)
+	if len(events) != 1 || events[0].Reason != notify.ReasonFetchRecovered {
+		t.Fatalf("recovery poll events = %+v", events)
+	}
+}
@@ -0,0 +1,111 @@
+package syncstatus
+
+import (
+	"context"
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/gitclient"
+	"github.com/guionardo/git_sync_status/internal/service"
+)
+
+// Analyzer inspects repositories. It is safe for concurrent use when its
+// Client, cache and history recorder are.
+type Analyzer struct {
+	inner   *service.Analyzer
+	timeout time.Duration
+}
+
+// New returns an Analyzer configured by opts.
+func New(opts ...Option) *Analyzer {
+	cfg := config{remote: DefaultRemote}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	client := gitclient.NewShellClient()
+	if cfg.runner != nil {
+		client = gitclient.NewShellClientWithRunner(cfg.runner)
+	}
+
+	inner := service.NewAnalyzer(client, cfg.remote).WithOptions(service.Options{
+		StaleAfter:  cfg.staleAfter,
+		MaxFileSize: cfg.maxFileSize,
+	})
+	inner.WithLFSRemote(cfg.lfs)
+	if cfg.cache != nil {
+		inner.WithCache(cfg.cache)
+	}
+	if cfg.history != nil {
+		inner.WithHistory(cfg.history)
+	}
+	return &Analyzer{inner: inner, timeout: cfg.timeout}
+}
+
+func (a *Analyzer) context(ctx context.Context) (context.Context, context.CancelFunc) {
+	if a.timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, a.timeout)
+}
+
+// Analyze fetches from the remote and returns the sync status of the
+// repository at repoPath. Failures are reported in the Result.
+func (a *Analyzer) Analyze(ctx context.Context, repoPath string) Result {
+	ctx, cancel := a.context(ctx)
+	defer cancel()
+	return fromResult(a.inner.Analyze(ctx, repoPath))
+}
+
+// LocalBranches lists the local branches of the repository at repoPath.
+func (a *Analyzer) LocalBranches(ctx context.Context, repoPath string) ([]BranchSummary, error) {
+	ctx, cancel := a.context(ctx)
+	defer cancel()
+	branches, err := a.inner.ScanLocalBranches(ctx, repoPath)
+	return convertSlice(branches, func(b service.BranchSummary) BranchSummary { return BranchSummary(b) }), err
+}
+
+// AllBranches returns the sync status and age of every local branch.
+func (a *Analyzer) AllBranches(ctx context.Context, repoPath string) ([]BranchStatus, error) {
+	ctx, cancel := a.context(ctx)
+	defer cancel()
+	branches, err := a.inner.AnalyzeAllBranches(ctx, repoPath)
+	return convertSlice(branches, fromBranch), err
+}
+
+// RemoteOnlyBranches lists remote branches not tracked by any local branch.
+func (a *Analyzer) RemoteOnlyBranches(ctx context.Context, repoPath string) ([]RemoteBranchStatus, error) {
+	ctx, cancel := a.context(ctx)
+	defer cancel()
+	branches, err := a.inner.ScanRemoteOnlyBranches(ctx, repoPath)
+	return convertSlice(branches, func(b service.RemoteBranchStatus) RemoteBranchStatus { return RemoteBranchStatus(b) }), err
+}
+
+// LostWork lists commits not reachable from any remote-tracking ref, grouped
+// by the branch, reflog or stash they were found in.
+func (a *Analyzer) LostWork(ctx context.Context, repoPath string) (LostWorkAudit, error) {
+	ctx, cancel := a.context(ctx)
+	defer cancel()
+	audit, err := a.inner.AuditLostWork(ctx, repoPath)
+	return fromLostWork(audit), err
+}
+
+// AnalyzeRepositories calls Analyze for each path, in order.
+func (a *Analyzer) AnalyzeRepositories(ctx context.Context, repoPaths []string) []Result {
+	results := make([]Result, 0, len(repoPaths))
+	for _, path := range repoPaths {
+		results = append(results, a.Analyze(ctx, path))
+	}
+	return results
+}
+
+// Report analyzes each repository and its branches for a sync report.
+func (a *Analyzer) Report(ctx context.Context, repoPaths []string) Report {
+	ctx, cancel := a.context(ctx)
+	defer cancel()
+	return fromReport(a.inner.BuildReport(ctx, repoPaths))
+}
+
+// DiscoverRepositories returns the repositories found under root, searching
+// at most maxDepth directory levels.
+func DiscoverRepositories(root string, maxDepth int) ([]string, error) {
+	return service.DiscoverRepositories(root, maxDepth)
+}
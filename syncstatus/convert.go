@@ -0,0 +1,221 @@
+package syncstatus
+
+import (
+	"context"
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+	"github.com/guionardo/git_sync_status/internal/gitclient"
+	"github.com/guionardo/git_sync_status/internal/lfs"
+	"github.com/guionardo/git_sync_status/internal/service"
+)
+
+func fromResult(r domain.Result) Result {
+	return Result{
+		RepoPath:             r.RepoPath,
+		Branch:               r.Branch,
+		Upstream:             r.Upstream,
+		Status:               Status(r.Status),
+		Behind:               r.Behind,
+		Ahead:                r.Ahead,
+		Flags:                r.Flags,
+		Actions:              r.Actions,
+		Details:              r.Details,
+		Err:                  r.Err,
+		NOUpstreamWasMerged:  r.NOUpstreamWasMerged,
+		NOUpstreamMergeBase:  r.NOUpstreamMergeBase,
+		NOUpstreamSuggestion: r.NOUpstreamSuggestion,
+		Conflicts:            convertSlice(r.Conflicts, func(c domain.ConflictPrediction) ConflictPrediction { return ConflictPrediction(c) }),
+		Submodules:           convertSlice(r.Submodules, fromSubmodule),
+		Worktrees:            convertSlice(r.Worktrees, func(w domain.WorktreeStatus) WorktreeStatus { return WorktreeStatus(w) }),
+		LFS:                  (*LFSStatus)(r.LFS),
+		Tags:                 fromTags(r.Tags),
+		Detached:             (*DetachedHeadStatus)(r.Detached),
+		Policy:               (*PolicyStatus)(r.Policy),
+		Findings:             convertSlice(r.Findings, func(f domain.RiskFinding) RiskFinding { return RiskFinding(f) }),
+	}
+}
+
+func toResult(r Result) domain.Result {
+	return domain.Result{
+		RepoPath:             r.RepoPath,
+		Branch:               r.Branch,
+		Upstream:             r.Upstream,
+		Status:               domain.Status(r.Status),
+		Behind:               r.Behind,
+		Ahead:                r.Ahead,
+		Flags:                r.Flags,
+		Actions:              r.Actions,
+		Details:              r.Details,
+		Err:                  r.Err,
+		NOUpstreamWasMerged:  r.NOUpstreamWasMerged,
+		NOUpstreamMergeBase:  r.NOUpstreamMergeBase,
+		NOUpstreamSuggestion: r.NOUpstreamSuggestion,
+		Conflicts:            convertSlice(r.Conflicts, func(c ConflictPrediction) domain.ConflictPrediction { return domain.ConflictPrediction(c) }),
+		Submodules:           convertSlice(r.Submodules, toSubmodule),
+		Worktrees:            convertSlice(r.Worktrees, func(w WorktreeStatus) domain.WorktreeStatus { return domain.WorktreeStatus(w) }),
+		LFS:                  (*domain.LFSStatus)(r.LFS),
+		Tags:                 toTags(r.Tags),
+		Detached:             (*domain.DetachedHeadStatus)(r.Detached),
+		Policy:               (*domain.PolicyStatus)(r.Policy),
+		Findings:             convertSlice(r.Findings, func(f RiskFinding) domain.RiskFinding { return domain.RiskFinding(f) }),
+	}
+}
+
+func fromSubmodule(s domain.SubmoduleStatus) SubmoduleStatus {
+	return SubmoduleStatus{
+		Path:             s.Path,
+		RecordedCommit:   s.RecordedCommit,
+		CheckedOutCommit: s.CheckedOutCommit,
+		Initialized:      s.Initialized,
+		AtRecordedCommit: s.AtRecordedCommit,
+		Dirty:            s.Dirty,
+		Branch:           s.Branch,
+		Upstream:         s.Upstream,
+		Status:           Status(s.Status),
+		Behind:           s.Behind,
+		Ahead:            s.Ahead,
+		Flags:            s.Flags,
+		Submodules:       convertSlice(s.Submodules, fromSubmodule),
+	}
+}
+
+func toSubmodule(s SubmoduleStatus) domain.SubmoduleStatus {
+	return domain.SubmoduleStatus{
+		Path:             s.Path,
+		RecordedCommit:   s.RecordedCommit,
+		CheckedOutCommit: s.CheckedOutCommit,
+		Initialized:      s.Initialized,
+		AtRecordedCommit: s.AtRecordedCommit,
+		Dirty:            s.Dirty,
+		Branch:           s.Branch,
+		Upstream:         s.Upstream,
+		Status:           domain.Status(s.Status),
+		Behind:           s.Behind,
+		Ahead:            s.Ahead,
+		Flags:            s.Flags,
+		Submodules:       convertSlice(s.Submodules, toSubmodule),
+	}
+}
+
+func fromTags(t *domain.TagStatus) *TagStatus {
+	if t == nil {
+		return nil
+	}
+	return &TagStatus{
+		LocalOnly:  t.LocalOnly,
+		RemoteOnly: t.RemoteOnly,
+		Mismatched: convertSlice(t.Mismatched, func(m domain.TagMismatch) TagMismatch { return TagMismatch(m) }),
+	}
+}
+
+func toTags(t *TagStatus) *domain.TagStatus {
+	if t == nil {
+		return nil
+	}
+	return &domain.TagStatus{
+		LocalOnly:  t.LocalOnly,
+		RemoteOnly: t.RemoteOnly,
+		Mismatched: convertSlice(t.Mismatched, func(m TagMismatch) domain.TagMismatch { return domain.TagMismatch(m) }),
+	}
+}
+
+func fromBranch(b service.BranchStatus) BranchStatus {
+	return BranchStatus{
+		RepoPath:         b.RepoPath,
+		Branch:           b.Branch,
+		Upstream:         b.Upstream,
+		Status:           Status(b.Status),
+		Behind:           b.Behind,
+		Ahead:            b.Ahead,
+		Flags:            b.Flags,
+		LastCommitDate:   b.LastCommitDate,
+		LastCommitAuthor: b.LastCommitAuthor,
+		CreatedAt:        b.CreatedAt,
+		CreatedApprox:    b.CreatedApprox,
+		Stale:            b.Stale,
+		DefaultBranch:    b.DefaultBranch,
+		DefaultBehind:    b.DefaultBehind,
+		DefaultAhead:     b.DefaultAhead,
+		Suggestion:       b.Suggestion,
+		Conflicts:        convertSlice(b.Conflicts, func(c domain.ConflictPrediction) ConflictPrediction { return ConflictPrediction(c) }),
+		Worktree:         b.Worktree,
+		Details:          b.Details,
+	}
+}
+
+func fromLostWork(a service.LostWorkAudit) LostWorkAudit {
+	return LostWorkAudit{
+		RepoPath: a.RepoPath,
+		Remote:   a.Remote,
+		Groups: convertSlice(a.Groups, func(g service.LostWorkGroup) LostWorkGroup {
+			return LostWorkGroup{
+				Source:  g.Source,
+				Commits: convertSlice(g.Commits, func(c service.LostCommit) LostCommit { return LostCommit(c) }),
+			}
+		}),
+		TotalCommits: a.TotalCommits,
+	}
+}
+
+func fromReport(r service.Report) Report {
+	return Report{
+		GeneratedAt: r.GeneratedAt,
+		Repositories: convertSlice(r.Repositories, func(repo service.RepositoryReport) RepositoryReport {
+			return RepositoryReport{
+				Result:   fromResult(repo.Result),
+				Branches: convertSlice(repo.Branches, fromBranch),
+				Err:      repo.Err,
+			}
+		}),
+	}
+}
+
+func convertSlice[From, To any](in []From, convert func(From) To) []To {
+	if in == nil {
+		return nil
+	}
+	out := make([]To, len(in))
+	for i, v := range in {
+		out[i] = convert(v)
+	}
+	return out
+}
+
+type clientRunner struct {
+	client Client
+}
+
+func (r clientRunner) Run(ctx context.Context, cmd gitclient.Command) error {
+	return r.client.RunGit(ctx, GitCommand(cmd))
+}
+
+type cacheAdapter struct {
+	cache ResultCache
+}
+
+func (a cacheAdapter) Get(repoPath string, variant string) (domain.Result, bool) {
+	result, ok := a.cache.Get(repoPath, variant)
+	return toResult(result), ok
+}
+
+func (a cacheAdapter) Put(repoPath string, variant string, result domain.Result) error {
+	return a.cache.Put(repoPath, variant, fromResult(result))
+}
+
+type historyAdapter struct {
+	recorder HistoryRecorder
+}
+
+func (a historyAdapter) Record(result domain.Result, at time.Time) error {
+	return a.recorder.Record(fromResult(result), at)
+}
+
+type lfsAdapter struct {
+	remote LFSRemote
+}
+
+func (a lfsAdapter) MissingObjects(ctx context.Context, endpoint lfs.Endpoint, objects []lfs.Object) ([]lfs.Object, error) {
+	missing, err := a.remote.MissingObjects(ctx, LFSEndpoint(endpoint), convertSlice(objects, func(o lfs.Object) LFSObject { return LFSObject(o) }))
+	return convertSlice(missing, func(o LFSObject) lfs.Object { return lfs.Object(o) }), err
+}
@@ -0,0 +1,105 @@
+package syncstatus
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/domain"
+	"github.com/guionardo/git_sync_status/internal/gitclient"
+	"github.com/guionardo/git_sync_status/internal/lfs"
+	"github.com/guionardo/git_sync_status/internal/service"
+)
+
+func TestPublicTypesCoverInternalFields(t *testing.T) {
+	t.Parallel()
+
+	pairs := []struct{ public, internal any }{
+		{Result{}, domain.Result{}},
+		{ConflictPrediction{}, domain.ConflictPrediction{}},
+		{SubmoduleStatus{}, domain.SubmoduleStatus{}},
+		{WorktreeStatus{}, domain.WorktreeStatus{}},
+		{LFSStatus{}, domain.LFSStatus{}},
+		{TagStatus{}, domain.TagStatus{}},
+		{TagMismatch{}, domain.TagMismatch{}},
+		{DetachedHeadStatus{}, domain.DetachedHeadStatus{}},
+		{PolicyStatus{}, domain.PolicyStatus{}},
+		{RiskFinding{}, domain.RiskFinding{}},
+		{BranchSummary{}, service.BranchSummary{}},
+		{BranchStatus{}, service.BranchStatus{}},
+		{RemoteBranchStatus{}, service.RemoteBranchStatus{}},
+		{LostWorkAudit{}, service.LostWorkAudit{}},
+		{LostWorkGroup{}, service.LostWorkGroup{}},
+		{LostCommit{}, service.LostCommit{}},
+		{Report{}, service.Report{}},
+		{RepositoryReport{}, service.RepositoryReport{}},
+		{GitCommand{}, gitclient.Command{}},
+		{LFSEndpoint{}, lfs.Endpoint{}},
+		{LFSObject{}, lfs.Object{}},
+	}
+	for _, pair := range pairs {
+		public, internal := reflect.TypeOf(pair.public), reflect.TypeOf(pair.internal)
+		if got, want := fieldNames(public), fieldNames(internal); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s fields = %v, want the fields of %s: %v", public, got, internal, want)
+		}
+	}
+}
+
+func TestResultRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	want := domain.Result{
+		RepoPath: "/src/api", Branch: "main", Upstream: "origin/main", Status: domain.StatusDiverged,
+		Behind: 2, Ahead: 1, Flags: []string{"WORKTREE_DIRTY"}, Actions: []string{"pull"}, Details: []string{"d"},
+		Err: "e", NOUpstreamWasMerged: true, NOUpstreamMergeBase: "main", NOUpstreamSuggestion: "delete",
+		Conflicts: []domain.ConflictPrediction{{Against: "origin/main", Files: []string{"go.mod"}}},
+		Submodules: []domain.SubmoduleStatus{{
+			Path: "lib", Status: domain.StatusLate, Initialized: true,
+			Submodules: []domain.SubmoduleStatus{{Path: "lib/inner", Dirty: true}},
+		}},
+		Worktrees: []domain.WorktreeStatus{{Path: "/src/api-wt", Branch: "feature", Locked: true}},
+		LFS:       &domain.LFSStatus{TrackedFiles: 3, UnpushedPaths: []string{"a.bin"}},
+		Tags:      &domain.TagStatus{LocalOnly: []string{"v1"}, Mismatched: []domain.TagMismatch{{Name: "v0"}}},
+		Detached:  &domain.DetachedHeadStatus{Commit: "abc", OnRemote: true},
+		Policy:    &domain.PolicyStatus{CheckedCommits: 2, UnsignedCommits: []string{"abc"}},
+		Findings:  []domain.RiskFinding{{Rule: "secret", Path: ".env"}},
+	}
+	if got := toResult(fromResult(want)); !reflect.DeepEqual(got, want) {
+		t.Fatalf("round trip = %+v, want %+v", got, want)
+	}
+}
+
+func TestDefaultsMatchAnalyzer(t *testing.T) {
+	t.Parallel()
+
+	if DefaultStaleAfter != service.DefaultStaleAfter || DefaultMaxFileSize != service.DefaultMaxFileSize {
+		t.Fatalf("defaults = %s, %d; analyzer uses %s, %d", DefaultStaleAfter, DefaultMaxFileSize, service.DefaultStaleAfter, service.DefaultMaxFileSize)
+	}
+	for _, status := range []Status{StatusNotAGitRepo, StatusNoRemote, StatusNoUpstream, StatusDetached, StatusSynced, StatusSyncPending, StatusLate, StatusDiverged} {
+		if domain.Status(status).Severity() == "" {
+			t.Errorf("status %s is unknown to the analyzer", status)
+		}
+	}
+}
+
+func TestWithStaleAfterZeroKeepsDefault(t *testing.T) {
+	t.Parallel()
+
+	var cfg config
+	WithStaleAfter(0)(&cfg)
+	if cfg.staleAfter != 0 {
+		t.Fatalf("WithStaleAfter(0) = %s, want 0 so the analyzer applies its default", cfg.staleAfter)
+	}
+	WithStaleAfter(-time.Hour)(&cfg)
+	if cfg.staleAfter != service.StaleCheckDisabled {
+		t.Fatalf("WithStaleAfter(-1h) = %s, want the check disabled", cfg.staleAfter)
+	}
+}
+
+func fieldNames(t reflect.Type) []string {
+	names := make([]string, t.NumField())
+	for i := range names {
+		names[i] = t.Field(i).Name
+	}
+	return names
+}
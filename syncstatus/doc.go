@@ -0,0 +1,16 @@
+// Package syncstatus is the public, embeddable API of git-sync-status.
+//
+// It exposes the same analyzer used by the CLI: create one with New and
+// functional options, then call Analyze or the branch, remote-branch,
+// lost-work and multi-repository methods. Results use this package's own
+// types, with the same fields the CLI prints with --json.
+//
+// Custom backends plug in through small interfaces: Client runs single git
+// commands, and ResultCache, HistoryRecorder and LFSRemote replace the
+// cache, the history store and the LFS server lookup.
+//
+// The package follows semantic versioning: within a major version, exported
+// identifiers are not removed or changed incompatibly, fields are only added
+// to result structs, and methods are never added to the exported interfaces.
+// Internal packages carry no such guarantee.
+package syncstatus
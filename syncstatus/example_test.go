@@ -0,0 +1,131 @@
+package syncstatus_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/guionardo/git_sync_status/syncstatus"
+)
+
+func git(dir string, args ...string) {
+	args = append([]string{"-c", "user.name=Example", "-c", "user.email=example@example.com", "-c", "init.defaultBranch=main"}, args...)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		panic(fmt.Sprintf("git %v: %v\n%s", args, err, out))
+	}
+}
+
+func Example() {
+	dir, _ := os.MkdirTemp("", "syncstatus-example")
+	defer os.RemoveAll(dir)
+	git(dir, "init", "-q")
+	git(dir, "commit", "-q", "--allow-empty", "-m", "initial")
+
+	analyzer := syncstatus.New()
+	result := analyzer.Analyze(context.Background(), dir)
+	fmt.Println(result.Branch, result.Status)
+	// Output: main NO_REMOTE
+}
+
+func ExampleNew() {
+	root, _ := os.MkdirTemp("", "syncstatus-example")
+	defer os.RemoveAll(root)
+	remote := filepath.Join(root, "remote.git")
+	work := filepath.Join(root, "work")
+	git(root, "init", "-q", "--bare", remote)
+	git(root, "clone", "-q", remote, work)
+	git(work, "commit", "-q", "--allow-empty", "-m", "initial")
+	git(work, "push", "-q", "-u", "origin", "main")
+	git(work, "commit", "-q", "--allow-empty", "-m", "not pushed yet")
+
+	analyzer := syncstatus.New(
+		syncstatus.WithRemote("origin"),
+		syncstatus.WithTimeout(time.Minute),
+		syncstatus.WithStaleAfter(30*24*time.Hour),
+	)
+	result := analyzer.Analyze(context.Background(), work)
+	fmt.Printf("%s -> %s: %s, ahead %d\n", result.Branch, result.Upstream, result.Status, result.Ahead)
+	// Output: main -> origin/main: SYNC_PENDING, ahead 1
+}
+
+type countingClient struct {
+	next     syncstatus.Client
+	commands int
+}
+
+func (c *countingClient) RunGit(ctx context.Context, cmd syncstatus.GitCommand) error {
+	c.commands++
+	return c.next.RunGit(ctx, cmd)
+}
+
+func ExampleWithClient() {
+	dir, _ := os.MkdirTemp("", "syncstatus-example")
+	defer os.RemoveAll(dir)
+	git(dir, "init", "-q")
+	git(dir, "commit", "-q", "--allow-empty", "-m", "initial")
+
+	client := &countingClient{next: syncstatus.NewShellClient()}
+	result := syncstatus.New(syncstatus.WithClient(client)).Analyze(context.Background(), dir)
+	fmt.Println(result.Status, client.commands > 0)
+	// Output: NO_REMOTE true
+}
+
+type memoryCache struct {
+	results map[string]syncstatus.Result
+	hits    int
+}
+
+func (c *memoryCache) Get(repoPath string, variant string) (syncstatus.Result, bool) {
+	result, ok := c.results[repoPath+variant]
+	if ok {
+		c.hits++
+	}
+	return result, ok
+}
+
+func (c *memoryCache) Put(repoPath string, variant string, result syncstatus.Result) error {
+	c.results[repoPath+variant] = result
+	return nil
+}
+
+func ExampleWithCache() {
+	root, _ := os.MkdirTemp("", "syncstatus-example")
+	defer os.RemoveAll(root)
+	dir := filepath.Join(root, "work")
+	git(root, "init", "-q", "--bare", "remote.git")
+	git(root, "clone", "-q", "remote.git", "work")
+	git(dir, "commit", "-q", "--allow-empty", "-m", "initial")
+	git(dir, "push", "-q", "-u", "origin", "HEAD")
+
+	cache := &memoryCache{results: map[string]syncstatus.Result{}}
+	analyzer := syncstatus.New(syncstatus.WithCache(cache))
+	analyzer.Analyze(context.Background(), dir)
+	result := analyzer.Analyze(context.Background(), dir)
+	fmt.Println(result.Status, "cache hits:", cache.hits)
+	// Output: SYNCED cache hits: 1
+}
+
+func ExampleAnalyzer_AllBranches() {
+	dir, _ := os.MkdirTemp("", "syncstatus-example")
+	defer os.RemoveAll(dir)
+	git(dir, "init", "-q")
+	git(dir, "commit", "-q", "--allow-empty", "-m", "initial")
+	git(dir, "branch", "feature/login")
+
+	rows, err := syncstatus.New().AllBranches(context.Background(), dir)
+	if err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+	for _, row := range rows {
+		fmt.Println(row.Branch, row.Status)
+	}
+	// Output:
+	// feature/login NO_UPSTREAM
+	// main NO_UPSTREAM
+}
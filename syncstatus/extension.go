@@ -0,0 +1,73 @@
+package syncstatus
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/gitclient"
+)
+
+// GitCommand is one git invocation requested by the analyzer. Args do not
+// include the git executable itself.
+type GitCommand struct {
+	Dir  string
+	Args []string
+	// Env holds extra KEY=value pairs added to the current environment.
+	Env    []string
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// Client runs the git commands behind an analysis. RunGit must write the
+// command's output to Stdout and Stderr and return an error when git fails;
+// when git exits with a non-zero status the error must have an
+// ExitCode() int method, as *exec.ExitError does.
+type Client interface {
+	RunGit(ctx context.Context, cmd GitCommand) error
+}
+
+// NewShellClient returns the Client used by the CLI, which runs the git
+// binary found on PATH.
+func NewShellClient() Client {
+	return shellClient{}
+}
+
+type shellClient struct{}
+
+func (shellClient) RunGit(ctx context.Context, cmd GitCommand) error {
+	return gitclient.ExecRunner{}.Run(ctx, gitclient.Command(cmd))
+}
+
+// ResultCache stores analysis results between runs; see WithCache. The
+// variant identifies the analyzer options a result was computed with.
+type ResultCache interface {
+	Get(repoPath string, variant string) (Result, bool)
+	Put(repoPath string, variant string, result Result) error
+}
+
+// HistoryRecorder stores every analysis; see WithHistory.
+type HistoryRecorder interface {
+	Record(result Result, at time.Time) error
+}
+
+// LFSEndpoint is the LFS server URL and the credentials resolved for it.
+type LFSEndpoint struct {
+	URL string
+	// Header holds the headers returned by git-lfs-authenticate for SSH remotes.
+	Header   map[string]string
+	Username string
+	Password string
+}
+
+// LFSObject is a Git LFS object identified by its OID and size.
+type LFSObject struct {
+	OID  string
+	Size int64
+}
+
+// LFSRemote asks an LFS server which of objects it does not have.
+type LFSRemote interface {
+	MissingObjects(ctx context.Context, endpoint LFSEndpoint, objects []LFSObject) ([]LFSObject, error)
+}
@@ -0,0 +1,105 @@
+package syncstatus
+
+import (
+	"time"
+
+	"github.com/guionardo/git_sync_status/internal/cache"
+	"github.com/guionardo/git_sync_status/internal/gitclient"
+	"github.com/guionardo/git_sync_status/internal/history"
+	"github.com/guionardo/git_sync_status/internal/service"
+)
+
+type config struct {
+	remote      string
+	timeout     time.Duration
+	runner      gitclient.Runner
+	lfs         service.LFSRemote
+	cache       service.ResultCache
+	history     service.HistoryRecorder
+	staleAfter  time.Duration
+	maxFileSize int64
+}
+
+// Option configures an Analyzer created by New.
+type Option func(*config)
+
+// WithRemote sets the remote compared against. The default is DefaultRemote.
+func WithRemote(name string) Option {
+	return func(c *config) { c.remote = name }
+}
+
+// WithTimeout bounds every Analyzer method call. Zero, the default, means no limit.
+func WithTimeout(d time.Duration) Option {
+	return func(c *config) { c.timeout = d }
+}
+
+// WithClient runs git through client instead of NewShellClient, for example
+// to log commands or to run them in a container.
+func WithClient(client Client) Option {
+	return func(c *config) {
+		c.runner = nil
+		if client != nil {
+			c.runner = clientRunner{client: client}
+		}
+	}
+}
+
+// WithLFSRemote replaces the client that asks the LFS server for missing objects.
+func WithLFSRemote(remote LFSRemote) Option {
+	return func(c *config) {
+		c.lfs = nil
+		if remote != nil {
+			c.lfs = lfsAdapter{remote: remote}
+		}
+	}
+}
+
+// WithCache reuses results from cache while the repository, including the
+// remote-tracking refs updated by the fetch, is unchanged. The working tree
+// is always re-checked.
+func WithCache(rc ResultCache) Option {
+	return func(c *config) {
+		c.cache = nil
+		if rc != nil {
+			c.cache = cacheAdapter{cache: rc}
+		}
+	}
+}
+
+// WithCacheDir caches results as files under dir, the same store the CLI
+// uses unless --no-cache is given, for at most maxAge.
+func WithCacheDir(dir string, maxAge time.Duration) Option {
+	return func(c *config) { c.cache = cache.NewStore(dir, maxAge) }
+}
+
+// WithHistory records every analysis, including cached ones, with recorder.
+func WithHistory(recorder HistoryRecorder) Option {
+	return func(c *config) {
+		c.history = nil
+		if recorder != nil {
+			c.history = historyAdapter{recorder: recorder}
+		}
+	}
+}
+
+// WithHistoryFile records every analysis in the bolt database at path, the
+// store the CLI uses with --history.
+func WithHistoryFile(path string) Option {
+	return func(c *config) { c.history = history.NewStore(path) }
+}
+
+// WithStaleAfter flags branches as STALE when their last commit and creation
+// are older than d. Zero keeps DefaultStaleAfter and a negative d disables
+// the check.
+func WithStaleAfter(d time.Duration) Option {
+	if d < 0 {
+		d = service.StaleCheckDisabled
+	}
+	return func(c *config) { c.staleAfter = d }
+}
+
+// WithMaxFileSize flags outgoing files larger than bytes as RISKY_PUSH. The
+// default is DefaultMaxFileSize.
+func WithMaxFileSize(bytes int64) Option {
+	return func(c *config) { c.maxFileSize = bytes }
+}
@@ -0,0 +1,241 @@
+package syncstatus
+
+import "time"
+
+// Status is the overall sync state of a repository or branch.
+type Status string
+
+// Overall repository states reported in Result.Status.
+const (
+	StatusNotAGitRepo Status = "NOT_A_GIT_REPO"
+	StatusNoRemote    Status = "NO_REMOTE"
+	StatusNoUpstream  Status = "NO_UPSTREAM"
+	StatusDetached    Status = "DETACHED"
+	StatusSynced      Status = "SYNCED"
+	StatusSyncPending Status = "SYNC_PENDING"
+	StatusLate        Status = "LATE"
+	StatusDiverged    Status = "DIVERGED"
+)
+
+// Result is the sync status of one repository, with the same fields the
+// CLI prints with --json.
+type Result struct {
+	RepoPath string
+	Branch   string
+	Upstream string
+	Status   Status
+	Behind   int
+	Ahead    int
+	// Flags name extra conditions such as WORKTREE_DIRTY or RISKY_PUSH.
+	Flags   []string
+	Actions []string
+	Details []string
+	// Err is the git error that stopped the analysis, if any.
+	Err string
+
+	NOUpstreamWasMerged  bool
+	NOUpstreamMergeBase  string
+	NOUpstreamSuggestion string
+
+	Conflicts  []ConflictPrediction
+	Submodules []SubmoduleStatus
+	Worktrees  []WorktreeStatus
+	LFS        *LFSStatus
+	Tags       *TagStatus
+	Detached   *DetachedHeadStatus
+	Policy     *PolicyStatus
+	Findings   []RiskFinding
+}
+
+// HasFlag reports whether the result carries flag.
+func (r Result) HasFlag(flag string) bool {
+	for _, f := range r.Flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// ConflictPrediction lists the files that would conflict when merging Against.
+type ConflictPrediction struct {
+	Against string
+	Files   []string
+}
+
+// SubmoduleStatus is the state of one submodule, analyzed without network access.
+type SubmoduleStatus struct {
+	Path             string
+	RecordedCommit   string
+	CheckedOutCommit string
+	Initialized      bool
+	AtRecordedCommit bool
+	Dirty            bool
+	Branch           string
+	Upstream         string
+	Status           Status
+	Behind           int
+	Ahead            int
+	Flags            []string
+	Submodules       []SubmoduleStatus
+}
+
+// InSync reports whether the submodule and all of its own submodules are
+// initialized, at the recorded commit and clean.
+func (s SubmoduleStatus) InSync() bool {
+	if !s.Initialized || !s.AtRecordedCommit || s.Dirty {
+		return false
+	}
+	for _, child := range s.Submodules {
+		if !child.InSync() {
+			return false
+		}
+	}
+	return true
+}
+
+// WorktreeStatus is the state of one linked worktree.
+type WorktreeStatus struct {
+	Path           string
+	Branch         string
+	Head           string
+	Current        bool
+	Detached       bool
+	Dirty          bool
+	Locked         bool
+	LockReason     string
+	Prunable       bool
+	PrunableReason string
+}
+
+// LFSStatus counts Git LFS objects and those missing on the LFS server.
+type LFSStatus struct {
+	TrackedFiles    int
+	LocalObjects    int
+	UnpushedObjects int
+	UnpushedBytes   int64
+	UnpushedPaths   []string
+	// Unknown is set when the LFS server could not be queried, for example
+	// because no credentials were available; the unpushed counts are then zero.
+	Unknown bool
+}
+
+// TagStatus lists tags that exist on only one side or differ between local and remote.
+type TagStatus struct {
+	LocalOnly  []string
+	RemoteOnly []string
+	Mismatched []TagMismatch
+}
+
+// TagMismatch is a tag that points to different commits locally and on the remote.
+type TagMismatch struct {
+	Name         string
+	LocalCommit  string
+	RemoteCommit string
+}
+
+// DetachedHeadStatus describes a detached HEAD and the refs containing it.
+type DetachedHeadStatus struct {
+	Commit         string
+	LocalBranches  []string
+	RemoteBranches []string
+	Tags           []string
+	OnRemote       bool
+}
+
+// PolicyStatus reports outgoing commits that break the commit policy.
+type PolicyStatus struct {
+	CheckedCommits       int
+	UnsignedCommits      []string
+	ForeignAuthorCommits []string
+}
+
+// RiskFinding is a risky file in the outgoing commits, such as a secret or a large file.
+type RiskFinding struct {
+	Rule   string
+	Commit string
+	Path   string
+	Detail string
+}
+
+// BranchSummary is one local branch as listed by LocalBranches.
+type BranchSummary struct {
+	Name string
+}
+
+// BranchStatus is the sync state and age of one local branch.
+type BranchStatus struct {
+	RepoPath         string
+	Branch           string
+	Upstream         string
+	Status           Status
+	Behind           int
+	Ahead            int
+	Flags            []string
+	LastCommitDate   time.Time
+	LastCommitAuthor string
+	CreatedAt        time.Time
+	// CreatedApprox is set when the reflog no longer records the creation and
+	// CreatedAt was estimated; Details says from what.
+	CreatedApprox bool
+	Stale         bool
+	DefaultBranch string
+	DefaultBehind int
+	DefaultAhead  int
+	Suggestion    string
+	Conflicts     []ConflictPrediction
+	Worktree      string
+	Details       []string
+}
+
+// RemoteBranchStatus is a remote branch not tracked by any local branch.
+type RemoteBranchStatus struct {
+	Branch           string
+	LastCommitAuthor string
+	LastCommitDate   time.Time
+	MergedInto       string
+	Merged           bool
+}
+
+// LostWorkAudit groups commits not reachable from any remote-tracking ref.
+type LostWorkAudit struct {
+	RepoPath     string
+	Remote       string
+	Groups       []LostWorkGroup
+	TotalCommits int
+}
+
+// LostWorkGroup is the set of lost commits found from one source, such as
+// a branch, the HEAD reflog or a stash.
+type LostWorkGroup struct {
+	Source  string
+	Commits []LostCommit
+}
+
+// LostCommit is one commit not reachable from any remote-tracking ref.
+type LostCommit struct {
+	SHA     string
+	Author  string
+	Date    time.Time
+	Subject string
+}
+
+// Report summarizes several repositories, as the report subcommand prints.
+type Report struct {
+	GeneratedAt  time.Time
+	Repositories []RepositoryReport
+}
+
+// RepositoryReport is one repository in a Report.
+type RepositoryReport struct {
+	Result   Result
+	Branches []BranchStatus
+	Err      string
+}
+
+// Defaults used by New when the matching option is not given.
+const (
+	DefaultRemote      = "origin"
+	DefaultStaleAfter  = 90 * 24 * time.Hour
+	DefaultMaxFileSize = 5 << 20
+)